@@ -0,0 +1,269 @@
+package url
+
+import (
+	"container/list"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultCacheSize is the number of the entries of a Cache if it is not given.
+	defaultCacheSize = 10000
+	// defaultDNSTTL, defaultHTTPTTL and defaultNegativeTTL are the TTLs of
+	// a Cache if they are not given.
+	defaultDNSTTL      = 5 * time.Minute
+	defaultHTTPTTL     = 10 * time.Minute
+	defaultNegativeTTL = time.Minute
+)
+
+// CacheOptions configures a Cache.
+type CacheOptions struct {
+	// Size is the number of the entries that the Cache holds. The least
+	// recently used entries are evicted when it is full. It is 10000 by default.
+	Size int
+	// DNSTTL is the time that a DNS result is cached for. It is 5 minutes by default.
+	// The TTLs of the records are not used, since *net.Resolver does not report them.
+	DNSTTL time.Duration
+	// HTTPTTL is the time that a live result is cached for. It is 10 minutes by default.
+	HTTPTTL time.Duration
+	// NegativeTTL is the time that a definite failure is cached for, e.g. an
+	// NXDOMAIN, a NODATA or a 404 status code. It is 1 minute by default.
+	// The temporary failures, such as timeouts, are not cached.
+	NegativeTTL time.Duration
+}
+
+// CacheStats are the statistics of a Cache.
+type CacheStats struct {
+	DNSHits    int
+	DNSMisses  int
+	HTTPHits   int
+	HTTPMisses int
+	// Evictions is the number of the entries that are evicted before they expire.
+	Evictions int
+	// Entries is the number of the entries in the Cache.
+	Entries int
+}
+
+// Cache caches the results of LookupDNS and CheckLive in a bounded LRU.
+// It is safe for concurrent use, so it can be shared by the URLs and the
+// workers of a Checker. The cached results must not be modified.
+//
+// Example Usage:
+//
+// cache := NewCache(CacheOptions{Size: 100000})
+// u.LookupDNS(ctx, DNSOptions{Cache: cache})
+// u.LookupDNS(ctx, DNSOptions{Cache: cache})
+// fmt.Println(cache.Stats().DNSHits) // 1
+type Cache struct {
+	opts CacheOptions
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	stats   CacheStats
+	// resolvers numbers the resolvers that the DNS results are looked up
+	// with, so the resolvers that share the Cache do not share their results.
+	resolvers map[Resolver]int
+}
+
+// cacheEntry is an entry of a Cache with its expiry.
+type cacheEntry struct {
+	key     string
+	value   interface{}
+	expires time.Time
+}
+
+// NewCache returns an empty Cache with the options.
+func NewCache(opts CacheOptions) *Cache {
+	if opts.Size <= 0 {
+		opts.Size = defaultCacheSize
+	}
+	if opts.DNSTTL <= 0 {
+		opts.DNSTTL = defaultDNSTTL
+	}
+	if opts.HTTPTTL <= 0 {
+		opts.HTTPTTL = defaultHTTPTTL
+	}
+	if opts.NegativeTTL <= 0 {
+		opts.NegativeTTL = defaultNegativeTTL
+	}
+	return &Cache{
+		opts:      opts,
+		now:       time.Now,
+		entries:   make(map[string]*list.Element),
+		lru:       list.New(),
+		resolvers: make(map[Resolver]int),
+	}
+}
+
+// Stats returns the statistics of the Cache.
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Entries = c.lru.Len()
+	return stats
+}
+
+// Purge removes all the entries of the Cache. The statistics are kept.
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+}
+
+// getDNS returns the cached DNS result of the host and the types that is
+// looked up with the resolver.
+func (c *Cache) getDNS(resolver Resolver, host string, types RecordType) (DNSResult, bool) {
+	value, ok := c.get(c.dnsCacheKey(resolver, host, types), &c.stats.DNSHits, &c.stats.DNSMisses)
+	if !ok {
+		return DNSResult{}, false
+	}
+	return value.(DNSResult), true
+}
+
+// setDNS caches the DNS result unless it is a temporary failure.
+func (c *Cache) setDNS(resolver Resolver, host string, types RecordType, result DNSResult) {
+	var ttl time.Duration
+	switch result.Status {
+	case DNSNoError:
+		ttl = c.opts.DNSTTL
+	case DNSNXDomain, DNSNoData:
+		ttl = c.opts.NegativeTTL
+	default:
+		return
+	}
+	c.set(c.dnsCacheKey(resolver, host, types), result, ttl)
+}
+
+// getHTTP returns the cached live result of the URL that is checked with the options.
+func (c *Cache) getHTTP(rawurl string, opts LiveOptions) (LiveResult, bool) {
+	value, ok := c.get(httpCacheKey(rawurl, opts), &c.stats.HTTPHits, &c.stats.HTTPMisses)
+	if !ok {
+		return LiveResult{}, false
+	}
+	return value.(LiveResult), true
+}
+
+// setHTTP caches the live result unless it is a temporary failure.
+func (c *Cache) setHTTP(rawurl string, opts LiveOptions, result LiveResult) {
+	var ttl time.Duration
+	switch result.ErrorClass {
+	case ErrorClassNone:
+		ttl = c.opts.HTTPTTL
+	case ErrorClassTLS, ErrorClassRedirect, ErrorClassStatus:
+		ttl = c.opts.NegativeTTL
+	case ErrorClassDNS:
+		// Only the hosts that are not found are definite failures.
+		if !isNotFound(result.Err) {
+			return
+		}
+		ttl = c.opts.NegativeTTL
+	default:
+		return
+	}
+	c.set(httpCacheKey(rawurl, opts), result, ttl)
+}
+
+// get returns the value of the key if it is not expired, and counts the hit or the miss.
+func (c *Cache) get(key string, hits, misses *int) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		*misses++
+		return nil, false
+	}
+	entry := element.Value.(*cacheEntry)
+	if !c.now().Before(entry.expires) {
+		c.lru.Remove(element)
+		delete(c.entries, key)
+		*misses++
+		return nil, false
+	}
+	c.lru.MoveToFront(element)
+	*hits++
+	return entry.value, true
+}
+
+// set caches the value of the key for the TTL and evicts the least recently
+// used entry if the Cache is full.
+func (c *Cache) set(key string, value interface{}, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &cacheEntry{key: key, value: value, expires: c.now().Add(ttl)}
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.lru.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+
+	if c.lru.Len() > c.opts.Size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+		c.stats.Evictions++
+	}
+}
+
+// dnsCacheKey returns the key of the DNS result of the host and the types
+// that is looked up with the resolver. A resolver whose type is not comparable
+// is told apart only by its type.
+func (c *Cache) dnsCacheKey(resolver Resolver, host string, types RecordType) string {
+	id := reflect.TypeOf(resolver).String()
+	if reflect.TypeOf(resolver).Comparable() {
+		c.mu.Lock()
+		n, ok := c.resolvers[resolver]
+		if !ok {
+			n = len(c.resolvers) + 1
+			c.resolvers[resolver] = n
+		}
+		c.mu.Unlock()
+		id = strconv.Itoa(n)
+	}
+	return "dns:" + id + ":" + strconv.FormatUint(uint64(types), 10) + ":" + host
+}
+
+// httpCacheKey returns the key of the live result of the URL. It includes the
+// options that change the result, so the checks with different options do not
+// share their results.
+func httpCacheKey(rawurl string, opts LiveOptions) string {
+	userAgent := opts.UserAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+	maxRedirects := opts.MaxRedirects
+	if maxRedirects == 0 {
+		maxRedirects = defaultMaxRedirects
+	}
+	ranges := opts.AcceptStatus
+	if len(ranges) == 0 {
+		ranges = defaultAcceptStatus
+	}
+
+	var b strings.Builder
+	b.WriteString("http:")
+	if opts.NoHEAD {
+		b.WriteString("get")
+	} else {
+		b.WriteString("head")
+	}
+	b.WriteString(":" + strconv.Itoa(maxRedirects) + ":")
+	for i, r := range ranges {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.Itoa(r.Min) + "-" + strconv.Itoa(r.Max))
+	}
+	// The User-Agent is quoted, since it may have any of the separators.
+	b.WriteString(":" + strconv.Quote(userAgent) + ":" + rawurl)
+	return b.String()
+}
@@ -0,0 +1,309 @@
+package url
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zeoagency/url/urltest"
+)
+
+// fakeClock is a clock that is moved by the tests.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestCacheDNS(t *testing.T) {
+	resolver := urltest.NewResolver()
+	resolver.AddIP("boratanrikulu.dev", "185.199.108.153")
+	resolver.AddIP("seo.do", "104.21.53.24")
+	resolver.Fail("broken.seo.do", urltest.ServFail("broken.seo.do"))
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	cache := NewCache(CacheOptions{DNSTTL: time.Minute, NegativeTTL: 10 * time.Second})
+	cache.now = clock.Now
+	opts := DNSOptions{Resolver: resolver, Types: RecordA, Cache: cache}
+
+	var testValues = []struct {
+		Input        string
+		Advance      time.Duration
+		WantedCached bool
+		WantedStatus DNSStatus
+	}{
+		{Input: "https://boratanrikulu.dev", WantedCached: false, WantedStatus: DNSNoError},
+		{Input: "https://blog.boratanrikulu.dev", WantedCached: false, WantedStatus: DNSNXDomain},
+		{Input: "https://broken.seo.do", WantedCached: false, WantedStatus: DNSServFail},
+		{Input: "https://seo.do", WantedCached: false, WantedStatus: DNSNoError},
+		{Input: "https://boratanrikulu.dev/blog", Advance: 5 * time.Second, WantedCached: true, WantedStatus: DNSNoError},
+		{Input: "https://blog.boratanrikulu.dev", WantedCached: true, WantedStatus: DNSNXDomain},
+		// The temporary failures are not cached.
+		{Input: "https://broken.seo.do", WantedCached: false, WantedStatus: DNSServFail},
+		// The negative TTL is passed.
+		{Input: "https://blog.boratanrikulu.dev", Advance: 5 * time.Second, WantedCached: false, WantedStatus: DNSNXDomain},
+		{Input: "https://boratanrikulu.dev", Advance: 20 * time.Second, WantedCached: true, WantedStatus: DNSNoError},
+		{Input: "https://seo.do", WantedCached: true, WantedStatus: DNSNoError},
+		// The DNSTTL is passed.
+		{Input: "https://seo.do", Advance: 30 * time.Second, WantedCached: false, WantedStatus: DNSNoError},
+	}
+
+	for i, testValue := range testValues {
+		u, err := NewURL(testValue.Input)
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Input)
+		}
+
+		clock.Add(testValue.Advance)
+		lookups := resolver.Lookups()
+		result := u.LookupDNS(context.Background(), opts)
+		if cached := resolver.Lookups() == lookups; cached != testValue.WantedCached {
+			t.Fatalf("[%d %s] Cached is wrong: Wanted: \"%t\" - Got: \"%t\"", i, testValue.Input, testValue.WantedCached, cached)
+		}
+		if result.Status != testValue.WantedStatus {
+			t.Fatalf("[%d %s] Status is wrong: Wanted: \"%s\" - Got: \"%s\"", i, testValue.Input, testValue.WantedStatus, result.Status)
+		}
+	}
+
+	stats := cache.Stats()
+	if stats.DNSHits != 4 || stats.DNSMisses != 7 || stats.HTTPHits != 0 || stats.HTTPMisses != 0 {
+		t.Fatalf("Stats are wrong: Got: %+v", stats)
+	}
+}
+
+func TestCacheDNSResolvers(t *testing.T) {
+	public := urltest.NewResolver()
+	public.AddIP("wiki.boratanrikulu.dev", "185.199.108.153")
+	private := urltest.NewResolver()
+	private.AddIP("wiki.boratanrikulu.dev", "10.0.0.2")
+
+	cache := NewCache(CacheOptions{})
+	u, err := NewURL("https://wiki.boratanrikulu.dev")
+	if err != nil {
+		t.Fatalf("Error occur: %s", err)
+	}
+
+	var testValues = []struct {
+		Resolver     *urltest.Resolver
+		WantedA      string
+		WantedCached bool
+	}{
+		{Resolver: public, WantedA: "185.199.108.153", WantedCached: false},
+		// The resolvers that share the Cache do not share their results.
+		{Resolver: private, WantedA: "10.0.0.2", WantedCached: false},
+		{Resolver: public, WantedA: "185.199.108.153", WantedCached: true},
+		{Resolver: private, WantedA: "10.0.0.2", WantedCached: true},
+	}
+
+	for i, testValue := range testValues {
+		lookups := testValue.Resolver.Lookups()
+		result := u.LookupDNS(context.Background(), DNSOptions{Resolver: testValue.Resolver, Types: RecordA, Cache: cache})
+		if cached := testValue.Resolver.Lookups() == lookups; cached != testValue.WantedCached {
+			t.Fatalf("[%d] Cached is wrong: Wanted: \"%t\" - Got: \"%t\"", i, testValue.WantedCached, cached)
+		}
+		if len(result.A) != 1 || result.A[0].String() != testValue.WantedA {
+			t.Fatalf("[%d] A is wrong: Wanted: \"%s\" - Got: %v", i, testValue.WantedA, result.A)
+		}
+	}
+}
+
+func TestCacheHTTP(t *testing.T) {
+	client := urltest.NewHTTPClient()
+	client.Handle("https://boratanrikulu.dev", urltest.Response{})
+	client.Handle("https://boratanrikulu.dev/missing", urltest.Response{StatusCode: 404})
+	client.Handle("https://boratanrikulu.dev/slow", urltest.Response{Delay: time.Second})
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	cache := NewCache(CacheOptions{HTTPTTL: time.Minute, NegativeTTL: 10 * time.Second})
+	cache.now = clock.Now
+	opts := LiveOptions{Client: client, NoHEAD: true, Timeout: 10 * time.Millisecond, Cache: cache}
+
+	var testValues = []struct {
+		Input        string
+		Advance      time.Duration
+		WantedCached bool
+		WantedLive   bool
+	}{
+		{Input: "https://boratanrikulu.dev", WantedCached: false, WantedLive: true},
+		{Input: "https://boratanrikulu.dev/missing", WantedCached: false},
+		{Input: "https://boratanrikulu.dev/slow", WantedCached: false},
+		{Input: "https://boratanrikulu.dev", Advance: 5 * time.Second, WantedCached: true, WantedLive: true},
+		{Input: "https://boratanrikulu.dev/missing", WantedCached: true},
+		{Input: "https://boratanrikulu.dev/slow", WantedCached: false},
+		{Input: "https://boratanrikulu.dev/missing", Advance: 5 * time.Second, WantedCached: false},
+		{Input: "https://boratanrikulu.dev", Advance: 50 * time.Second, WantedCached: false, WantedLive: true},
+	}
+
+	for i, testValue := range testValues {
+		u, err := NewURL(testValue.Input)
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Input)
+		}
+
+		clock.Add(testValue.Advance)
+		requests := len(client.Requests())
+		result := u.CheckLive(context.Background(), opts)
+		if cached := len(client.Requests()) == requests; cached != testValue.WantedCached {
+			t.Fatalf("[%d %s] Cached is wrong: Wanted: \"%t\" - Got: \"%t\"", i, testValue.Input, testValue.WantedCached, cached)
+		}
+		if result.Live != testValue.WantedLive {
+			t.Fatalf("[%d %s] Live is wrong: Wanted: \"%t\" - Got: \"%t\"", i, testValue.Input, testValue.WantedLive, result.Live)
+		}
+	}
+
+	if stats := cache.Stats(); stats.HTTPHits != 2 || stats.HTTPMisses != 6 {
+		t.Fatalf("Stats are wrong: Got: %+v", stats)
+	}
+}
+
+func TestCacheHTTPOptions(t *testing.T) {
+	client := urltest.NewHTTPClient()
+	client.Handle("https://boratanrikulu.dev", urltest.Response{StatusCode: 403})
+
+	cache := NewCache(CacheOptions{})
+	u, err := NewURL("https://boratanrikulu.dev")
+	if err != nil {
+		t.Fatalf("Error occur: %s", err)
+	}
+
+	var testValues = []struct {
+		Options      LiveOptions
+		WantedCached bool
+		WantedLive   bool
+	}{
+		{Options: LiveOptions{}, WantedCached: false},
+		{Options: LiveOptions{UserAgent: DefaultUserAgent, MaxRedirects: defaultMaxRedirects}, WantedCached: true},
+		{Options: LiveOptions{AcceptStatus: []StatusRange{{200, 403}}}, WantedCached: false, WantedLive: true},
+		{Options: LiveOptions{AcceptStatus: []StatusRange{{200, 403}}}, WantedCached: true, WantedLive: true},
+		{Options: LiveOptions{NoHEAD: true}, WantedCached: false},
+		{Options: LiveOptions{UserAgent: "my-crawler/1.0"}, WantedCached: false},
+		{Options: LiveOptions{MaxRedirects: 2}, WantedCached: false},
+	}
+
+	for i, testValue := range testValues {
+		opts := testValue.Options
+		opts.Client, opts.Cache = client, cache
+
+		requests := len(client.Requests())
+		result := u.CheckLive(context.Background(), opts)
+		if cached := len(client.Requests()) == requests; cached != testValue.WantedCached {
+			t.Fatalf("[%d] Cached is wrong: Wanted: \"%t\" - Got: \"%t\"", i, testValue.WantedCached, cached)
+		}
+		if result.Live != testValue.WantedLive {
+			t.Fatalf("[%d] Live is wrong: Wanted: \"%t\" - Got: \"%t\"", i, testValue.WantedLive, result.Live)
+		}
+	}
+}
+
+func TestCacheHTTPDNSError(t *testing.T) {
+	client := urltest.NewHTTPClient()
+	client.Fail("https://missing.boratanrikulu.dev", urltest.NotFound("missing.boratanrikulu.dev"))
+	client.Fail("https://broken.boratanrikulu.dev", urltest.ServFail("broken.boratanrikulu.dev"))
+	client.Fail("https://slow.boratanrikulu.dev", urltest.Timeout("slow.boratanrikulu.dev"))
+
+	cache := NewCache(CacheOptions{})
+	opts := LiveOptions{Client: client, NoHEAD: true, Cache: cache}
+
+	var testValues = []struct {
+		Input            string
+		WantedErrorClass ErrorClass
+		WantedCached     bool
+	}{
+		// Only the hosts that are not found are cached.
+		{Input: "https://missing.boratanrikulu.dev", WantedErrorClass: ErrorClassDNS, WantedCached: true},
+		{Input: "https://broken.boratanrikulu.dev", WantedErrorClass: ErrorClassDNS, WantedCached: false},
+		{Input: "https://slow.boratanrikulu.dev", WantedErrorClass: ErrorClassTimeout, WantedCached: false},
+	}
+
+	for _, testValue := range testValues {
+		u, err := NewURL(testValue.Input)
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Input)
+		}
+
+		result := u.CheckLive(context.Background(), opts)
+		if result.ErrorClass != testValue.WantedErrorClass {
+			t.Fatalf("[%s] ErrorClass is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Input, testValue.WantedErrorClass, result.ErrorClass)
+		}
+
+		requests := len(client.Requests())
+		u.CheckLive(context.Background(), opts)
+		if cached := len(client.Requests()) == requests; cached != testValue.WantedCached {
+			t.Fatalf("[%s] Cached is wrong: Wanted: \"%t\" - Got: \"%t\"", testValue.Input, testValue.WantedCached, cached)
+		}
+	}
+}
+
+func TestCacheLRU(t *testing.T) {
+	resolver := urltest.NewResolver()
+	cache := NewCache(CacheOptions{Size: 2})
+	cache.setDNS(resolver, "a.com", RecordA, DNSResult{Status: DNSNoError})
+	cache.setDNS(resolver, "b.com", RecordA, DNSResult{Status: DNSNoError})
+	cache.getDNS(resolver, "a.com", RecordA)
+	cache.setDNS(resolver, "c.com", RecordA, DNSResult{Status: DNSNoError})
+
+	var testValues = []struct {
+		Host   string
+		Wanted bool
+	}{
+		{Host: "a.com", Wanted: true},
+		{Host: "b.com", Wanted: false},
+		{Host: "c.com", Wanted: true},
+	}
+
+	for _, testValue := range testValues {
+		if _, ok := cache.getDNS(resolver, testValue.Host, RecordA); ok != testValue.Wanted {
+			t.Fatalf("[%s] Cached is wrong: Wanted: \"%t\" - Got: \"%t\"", testValue.Host, testValue.Wanted, ok)
+		}
+	}
+
+	if stats := cache.Stats(); stats.Evictions != 1 || stats.Entries != 2 {
+		t.Fatalf("Stats are wrong: Got: %+v", stats)
+	}
+	cache.Purge()
+	if stats := cache.Stats(); stats.Entries != 0 {
+		t.Fatalf("Cache is not purged: Got: %+v", stats)
+	}
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	resolver := urltest.NewResolver()
+	cache := NewCache(CacheOptions{Size: 50})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				host := fmt.Sprintf("host%d.boratanrikulu.dev", (i*j)%80)
+				resolver.AddIP(host, "185.199.108.153")
+				u, err := NewURL("https://" + host)
+				if err != nil {
+					t.Errorf("Error occur: %s", err)
+					return
+				}
+				u.LookupDNS(context.Background(), DNSOptions{Resolver: resolver, Types: RecordA, Cache: cache})
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	stats := cache.Stats()
+	if stats.DNSHits+stats.DNSMisses != 800 || stats.Entries > 50 {
+		t.Fatalf("Stats are wrong: Got: %+v", stats)
+	}
+}
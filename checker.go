@@ -0,0 +1,456 @@
+package url
+
+import (
+	"context"
+	"math/rand"
+	nethttp "net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultWorkers is the number of the workers of a Checker if it is not given.
+	defaultWorkers = 10
+	// defaultBackoff is the delay before the first retry if it is not given.
+	defaultBackoff = 500 * time.Millisecond
+)
+
+// Checks selects the checks that a Checker runs.
+// The checks can be combined with "|", e.g. CheckDNS | CheckHTTP.
+type Checks uint
+
+const (
+	// CheckDNS runs LookupDNS.
+	CheckDNS Checks = 1 << iota
+	// CheckHTTP runs CheckLive. It is skipped if the domain is not found by CheckDNS.
+	CheckHTTP
+)
+
+// Checker checks lists of URLs concurrently with a pool of workers.
+// The zero value is ready to use and runs both of the checks with 10 workers
+// without any limits. A Checker must not be modified while it is checking.
+//
+// Example Usage:
+//
+// c := &Checker{Workers: 50, PerHost: 2, DomainRate: 5, Retries: 2}
+// results := c.CheckAll(ctx, []string{"https://boratanrikulu.dev", "https://seo.do"})
+// result := <-results
+// fmt.Println(result.Input, result.DNS.Status, result.Live.StatusCode) // "https://boratanrikulu.dev" "NOERROR" 200
+type Checker struct {
+	// Parser parses the URLs. It is DefaultParser() by default.
+	Parser *Parser
+	// Checks are the checks to run. It is CheckDNS | CheckHTTP by default.
+	Checks Checks
+	// DNSOptions and LiveOptions configure the checks.
+	DNSOptions  DNSOptions
+	LiveOptions LiveOptions
+
+	// Workers is the number of the URLs that are checked at the same time.
+	// It is 10 by default.
+	Workers int
+	// PerHost is the number of the URLs of a host that are checked at the same
+	// time. It is unlimited if it is 0.
+	PerHost int
+	// Rate is the number of the checks per second in total, and DomainRate is
+	// the number of them per registrable domain, e.g. "boratanrikulu.dev".
+	// They are unlimited if they are 0. Each retry counts as a check.
+	Rate       float64
+	DomainRate float64
+	// Retries is the number of the retries of a check that fails temporarily,
+	// e.g. by a timeout, a SERVFAIL or a 503 status code.
+	Retries int
+	// Backoff is the delay before the first retry. It is doubled for each of
+	// the next retries, and a random jitter up to its half is added.
+	// It is 500 milliseconds by default.
+	Backoff time.Duration
+}
+
+// CheckResult is the result of a URL that a Checker checks.
+type CheckResult struct {
+	// Index is the position of the URL in the input.
+	Index int
+	Input string
+	// URL is nil if the input can not be parsed.
+	URL *URL
+	// DNS and Live are nil if their checks are not run.
+	DNS  *DNSResult
+	Live *LiveResult
+	// Attempts is the number of the times that the checks are run.
+	Attempts int
+	// Err is the error of the parsing, or the error of the context if the
+	// check is canceled.
+	Err error
+}
+
+// checkTask is a URL to check with its position in the input, and the
+// result of its attempts so far.
+type checkTask struct {
+	index  int
+	url    *URL
+	host   string
+	domain string
+	result CheckResult
+	// retry tells whether the last attempt failed temporarily, and notBefore
+	// is the time that the next attempt can be run at.
+	retry     bool
+	notBefore time.Time
+}
+
+// CheckAll checks the URLs and streams the results as they complete.
+// It is a shorthand for Check with a channel of the URLs.
+func (c *Checker) CheckAll(ctx context.Context, urls []string) <-chan CheckResult {
+	input := make(chan string)
+	go func() {
+		defer close(input)
+		for _, rawurl := range urls {
+			select {
+			case input <- rawurl:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return c.Check(ctx, input)
+}
+
+// Check checks the URLs that are received from the channel until it is closed,
+// and streams the results as they complete. The results channel is closed
+// when all the URLs are checked.
+//
+// A URL whose host has PerHost running checks, or whose domain has used its
+// DomainRate, waits in a queue while the URLs of the other hosts are checked,
+// so a slow host does not hold the workers. The URLs are received only when a
+// worker is idle.
+//
+// When the context is done, the URLs are not received from the channel
+// anymore, the running checks are canceled, and the results that are not
+// received yet may be dropped.
+func (c *Checker) Check(ctx context.Context, urls <-chan string) <-chan CheckResult {
+	workers := c.Workers
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+	s := newCheckScheduler(c, workers)
+
+	results := make(chan CheckResult)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range s.ready {
+				if task.url != nil {
+					c.attempt(ctx, task)
+				}
+				// The task is not used after it is given back if it is retried,
+				// since the scheduler may hand it to another worker.
+				retry, result := task.retry, task.result
+				select {
+				case s.done <- task:
+				case <-ctx.Done():
+					return
+				}
+				if retry {
+					continue
+				}
+				select {
+				case results <- result:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go s.run(ctx, urls)
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
+
+// attempt runs the checks of the task once. It sets the retry of the task
+// if the checks fail temporarily and the task has retries left.
+func (c *Checker) attempt(ctx context.Context, task *checkTask) {
+	task.retry = false
+	result := &task.result
+	result.Attempts++
+
+	checks := c.Checks
+	if checks == 0 {
+		checks = CheckDNS | CheckHTTP
+	}
+
+	retry := false
+	if checks&CheckDNS != 0 {
+		dns := task.url.LookupDNS(ctx, c.DNSOptions)
+		result.DNS = &dns
+		retry = dns.Status == DNSTimeout || dns.Status == DNSServFail || dns.Status == DNSError
+	}
+	notFound := result.DNS != nil && result.DNS.Status == DNSNXDomain && task.url.HostType == HostDomain
+	if checks&CheckHTTP != 0 && !notFound {
+		live := task.url.CheckLive(ctx, c.LiveOptions)
+		result.Live = &live
+		retry = retry || retryLive(live)
+	}
+
+	if ctx.Err() != nil {
+		result.Err = ctx.Err()
+		return
+	}
+	if !retry || result.Attempts > c.Retries {
+		return
+	}
+
+	backoff := c.Backoff
+	if backoff <= 0 {
+		backoff = defaultBackoff
+	}
+	delay := backoff << uint(result.Attempts-1)
+	delay += time.Duration(rand.Int63n(int64(delay)/2 + 1))
+	task.retry = true
+	task.notBefore = time.Now().Add(delay)
+}
+
+// retryLive tells whether the liveness check failed temporarily.
+func retryLive(live LiveResult) bool {
+	switch live.ErrorClass {
+	case ErrorClassTimeout, ErrorClassConnection, ErrorClassOther:
+		return true
+	case ErrorClassDNS:
+		return !isNotFound(live.Err)
+	case ErrorClassStatus:
+		return live.StatusCode == nethttp.StatusTooManyRequests || live.StatusCode >= 500
+	}
+	return false
+}
+
+// checkDomain returns the registrable domain of the URL that the domain
+// rate limit is applied to, or the IP address.
+func checkDomain(u *URL) string {
+	if domain := u.RegistrableDomain(); domain != "" {
+		return domain
+	}
+	return u.FullDomain
+}
+
+// checkScheduler hands the tasks of a Check call to the workers. It is run
+// by a single goroutine, so its state is not locked. A task is handed only
+// when a worker is idle, its host has a free slot, and the domain and the
+// global rate limits allow it, in that order.
+type checkScheduler struct {
+	parser  *Parser
+	perHost int
+
+	// ready is buffered by the number of the workers, so handing a task to
+	// an idle worker does not block. done receives the tasks back.
+	ready chan *checkTask
+	done  chan *checkTask
+	idle  int
+
+	pending    []*checkTask
+	running    map[string]int
+	rate       *rateLimiter
+	domainRate float64
+	domains    map[string]*rateLimiter
+}
+
+// newCheckScheduler returns the scheduler of a Check call of the Checker.
+func newCheckScheduler(c *Checker, workers int) *checkScheduler {
+	parser := c.Parser
+	if parser == nil {
+		parser = defaultParser
+	}
+	return &checkScheduler{
+		parser:     parser,
+		perHost:    c.PerHost,
+		ready:      make(chan *checkTask, workers),
+		done:       make(chan *checkTask),
+		idle:       workers,
+		running:    make(map[string]int),
+		rate:       newRateLimiter(c.Rate),
+		domainRate: c.DomainRate,
+		domains:    make(map[string]*rateLimiter),
+	}
+}
+
+// run receives the URLs and hands the tasks to the workers until all of them
+// are checked or the context is done. It closes the ready channel when it returns.
+func (s *checkScheduler) run(ctx context.Context, urls <-chan string) {
+	defer close(s.ready)
+
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+	defer timer.Stop()
+
+	for index := 0; ; {
+		wake := s.dispatch(time.Now())
+		if urls == nil && len(s.pending) == 0 && s.idle == cap(s.ready) {
+			return
+		}
+
+		var input <-chan string
+		if s.idle > 0 {
+			input = urls
+		}
+		var alarm <-chan time.Time
+		if !wake.IsZero() {
+			timer.Reset(time.Until(wake))
+			alarm = timer.C
+		}
+
+		select {
+		case rawurl, ok := <-input:
+			if !ok {
+				urls = nil
+				break
+			}
+			s.add(index, rawurl)
+			index++
+		case task := <-s.done:
+			s.idle++
+			if task.url != nil {
+				s.release(task.host)
+			}
+			if task.retry {
+				s.pending = append(s.pending, task)
+			}
+		case <-alarm:
+		case <-ctx.Done():
+			return
+		}
+
+		if alarm != nil && !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+	}
+}
+
+// add parses the URL and queues its task. The task of a URL that can not be
+// parsed is handed to a worker at once, since it is not checked.
+func (s *checkScheduler) add(index int, rawurl string) {
+	task := &checkTask{index: index, result: CheckResult{Index: index, Input: rawurl}}
+	u, err := s.parser.Parse(rawurl)
+	if err != nil {
+		task.result.Err = err
+		s.idle--
+		s.ready <- task
+		return
+	}
+	task.url, task.host, task.domain = u, u.FullDomain, checkDomain(u)
+	task.result.URL = u
+	s.pending = append(s.pending, task)
+}
+
+// dispatch hands the pending tasks that are allowed to run to the idle workers
+// in the order they are queued. It returns the time that a waiting task may be
+// allowed at, or the zero time if the tasks wait only for the running checks.
+func (s *checkScheduler) dispatch(now time.Time) time.Time {
+	var wake time.Time
+	later := func(t time.Time) {
+		if wake.IsZero() || t.Before(wake) {
+			wake = t
+		}
+	}
+
+	kept := s.pending[:0]
+	for i, task := range s.pending {
+		if s.idle == 0 {
+			kept = append(kept, s.pending[i:]...)
+			break
+		}
+		if task.notBefore.After(now) {
+			later(task.notBefore)
+			kept = append(kept, task)
+			continue
+		}
+		if s.perHost > 0 && s.running[task.host] >= s.perHost {
+			kept = append(kept, task)
+			continue
+		}
+		// The domain limit is checked before the global one, so a task that
+		// waits for its domain does not use a global turn.
+		domain := s.domain(task.domain)
+		if at := domain.reserve(now); at.After(now) {
+			later(at)
+			kept = append(kept, task)
+			continue
+		}
+		if at := s.rate.reserve(now); at.After(now) {
+			later(at)
+			kept = append(kept, s.pending[i:]...)
+			break
+		}
+		domain.take(now)
+		s.rate.take(now)
+
+		s.running[task.host]++
+		s.idle--
+		s.ready <- task
+	}
+	for i := len(kept); i < len(s.pending); i++ {
+		s.pending[i] = nil
+	}
+	s.pending = kept
+	return wake
+}
+
+// domain returns the rate limiter of the domain, or nil if there is no domain rate.
+func (s *checkScheduler) domain(domain string) *rateLimiter {
+	if s.domainRate <= 0 {
+		return nil
+	}
+	limiter, ok := s.domains[domain]
+	if !ok {
+		limiter = newRateLimiter(s.domainRate)
+		s.domains[domain] = limiter
+	}
+	return limiter
+}
+
+// release frees a slot of the host, and forgets the host when it has no
+// running checks anymore.
+func (s *checkScheduler) release(host string) {
+	s.running[host]--
+	if s.running[host] <= 0 {
+		delete(s.running, host)
+	}
+}
+
+// rateLimiter spaces the events evenly by the rate per second.
+// A nil rateLimiter does not limit.
+type rateLimiter struct {
+	interval time.Duration
+	next     time.Time
+}
+
+// newRateLimiter returns a limiter for the rate, or nil if the rate is not positive.
+func newRateLimiter(rate float64) *rateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	return &rateLimiter{interval: time.Duration(float64(time.Second) / rate)}
+}
+
+// reserve returns the time of the next turn. It is not after now if an event
+// is allowed now.
+func (l *rateLimiter) reserve(now time.Time) time.Time {
+	if l == nil || l.next.Before(now) {
+		return now
+	}
+	return l.next
+}
+
+// take uses the turn of an event at now.
+func (l *rateLimiter) take(now time.Time) {
+	if l == nil {
+		return
+	}
+	if l.next.Before(now) {
+		l.next = now
+	}
+	l.next = l.next.Add(l.interval)
+}
@@ -0,0 +1,241 @@
+package url
+
+import (
+	"context"
+	"errors"
+	nethttp "net/http"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zeoagency/url/urltest"
+)
+
+// concurrencyClient records the maximum number of the requests that are
+// sent to a host at the same time.
+type concurrencyClient struct {
+	HTTPClient
+
+	mu      sync.Mutex
+	running map[string]int
+	max     map[string]int
+}
+
+func (c *concurrencyClient) Do(req *nethttp.Request) (*nethttp.Response, error) {
+	c.mu.Lock()
+	c.running[req.URL.Host]++
+	if c.running[req.URL.Host] > c.max[req.URL.Host] {
+		c.max[req.URL.Host] = c.running[req.URL.Host]
+	}
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		c.running[req.URL.Host]--
+		c.mu.Unlock()
+	}()
+	return c.HTTPClient.Do(req)
+}
+
+func TestChecker(t *testing.T) {
+	resolver := urltest.NewResolver()
+	resolver.AddIP("boratanrikulu.dev", "185.199.108.153")
+	resolver.AddIP("seo.do", "104.21.53.24")
+	resolver.Fail("broken.seo.do", urltest.ServFail("broken.seo.do"))
+
+	client := urltest.NewHTTPClient()
+	client.Handle("https://boratanrikulu.dev", urltest.Response{StatusCode: 200})
+	client.Handle("https://seo.do", urltest.Response{StatusCode: 503})
+
+	c := &Checker{
+		DNSOptions:  DNSOptions{Resolver: resolver, Types: RecordA},
+		LiveOptions: LiveOptions{Client: client, NoHEAD: true},
+		Workers:     3,
+		Retries:     2,
+		Backoff:     time.Millisecond,
+	}
+
+	var testValues = []struct {
+		Input          string
+		WantedErr      bool
+		WantedStatus   DNSStatus
+		WantedLive     bool
+		WantedHTTP     bool
+		WantedAttempts int
+	}{
+		{Input: "https://boratanrikulu.dev", WantedStatus: DNSNoError, WantedLive: true, WantedHTTP: true, WantedAttempts: 1},
+		{Input: "https://seo.do", WantedStatus: DNSNoError, WantedHTTP: true, WantedAttempts: 3},
+		{Input: "https://nope.seo.do", WantedStatus: DNSNXDomain, WantedAttempts: 1},
+		{Input: "https://broken.seo.do", WantedStatus: DNSServFail, WantedHTTP: true, WantedAttempts: 3},
+		{Input: "not a url", WantedErr: true},
+	}
+
+	var urls []string
+	for _, testValue := range testValues {
+		urls = append(urls, testValue.Input)
+	}
+
+	var results []CheckResult
+	for result := range c.CheckAll(context.Background(), urls) {
+		results = append(results, result)
+	}
+	if len(results) != len(testValues) {
+		t.Fatalf("Results are wrong: Wanted: \"%d\" - Got: \"%d\"", len(testValues), len(results))
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Index < results[j].Index })
+
+	for i, testValue := range testValues {
+		result := results[i]
+		if result.Input != testValue.Input {
+			t.Fatalf("[%s] Input is wrong: Got: \"%s\"", testValue.Input, result.Input)
+		}
+		if testValue.WantedErr {
+			if result.Err == nil || result.URL != nil {
+				t.Fatalf("[%s] Error must be occurred, but did not", testValue.Input)
+			}
+			continue
+		}
+		if result.Err != nil {
+			t.Fatalf("Error occur: %s - %s", result.Err, testValue.Input)
+		}
+
+		if result.DNS == nil || result.DNS.Status != testValue.WantedStatus {
+			t.Fatalf("[%s] DNS result is wrong: Wanted: \"%s\" - Got: %+v", testValue.Input, testValue.WantedStatus, result.DNS)
+		}
+		if (result.Live != nil) != testValue.WantedHTTP {
+			t.Fatalf("[%s] HTTP check must be run: \"%t\"", testValue.Input, testValue.WantedHTTP)
+		}
+		if result.Live != nil && result.Live.Live != testValue.WantedLive {
+			t.Fatalf("[%s] Live is wrong: Wanted: \"%t\" - Got: \"%t\"", testValue.Input, testValue.WantedLive, result.Live.Live)
+		}
+		if result.Attempts != testValue.WantedAttempts {
+			t.Fatalf("[%s] Attempts are wrong: Wanted: \"%d\" - Got: \"%d\"", testValue.Input, testValue.WantedAttempts, result.Attempts)
+		}
+	}
+}
+
+func TestCheckerPerHost(t *testing.T) {
+	fake := urltest.NewHTTPClient()
+	var urls []string
+	for _, rawurl := range []string{"https://boratanrikulu.dev/a", "https://boratanrikulu.dev/b", "https://boratanrikulu.dev/c", "https://boratanrikulu.dev/d", "https://seo.do/a", "https://seo.do/b"} {
+		fake.Handle(rawurl, urltest.Response{Delay: 20 * time.Millisecond})
+		urls = append(urls, rawurl)
+	}
+	client := &concurrencyClient{HTTPClient: fake, running: make(map[string]int), max: make(map[string]int)}
+
+	c := &Checker{Checks: CheckHTTP, LiveOptions: LiveOptions{Client: client}, Workers: 6, PerHost: 2}
+	for result := range c.CheckAll(context.Background(), urls) {
+		if result.Live == nil || !result.Live.Live {
+			t.Fatalf("[%s] Live is wrong: Got: %+v", result.Input, result.Live)
+		}
+	}
+
+	for host, max := range client.max {
+		if max > 2 {
+			t.Fatalf("[%s] Requests are not limited per host: Wanted: \"%d\" - Got: \"%d\"", host, 2, max)
+		}
+	}
+}
+
+func TestCheckerSaturatedHost(t *testing.T) {
+	client := urltest.NewHTTPClient()
+	slow := []string{"https://boratanrikulu.dev/a", "https://boratanrikulu.dev/b", "https://boratanrikulu.dev/c"}
+	for _, rawurl := range slow {
+		client.Handle(rawurl, urltest.Response{Delay: 200 * time.Millisecond})
+	}
+	client.Handle("https://seo.do", urltest.Response{})
+
+	var testValues = []struct {
+		Checker Checker
+	}{
+		// The checks of "boratanrikulu.dev" wait for its slot, not the workers.
+		{Checker: Checker{Workers: 2, PerHost: 1}},
+		// The checks of "boratanrikulu.dev" wait for its rate, not the workers.
+		{Checker: Checker{Workers: 2, DomainRate: 5}},
+	}
+
+	for i, testValue := range testValues {
+		c := testValue.Checker
+		c.Checks, c.LiveOptions = CheckHTTP, LiveOptions{Client: client}
+
+		start := time.Now()
+		results := c.CheckAll(context.Background(), append(slow, "https://seo.do"))
+		result := <-results
+		if result.Input != "https://seo.do" {
+			t.Fatalf("[%d] Other hosts are blocked by a saturated host: Wanted: \"%s\" - Got: \"%s\"", i, "https://seo.do", result.Input)
+		}
+		if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+			t.Fatalf("[%d] Other hosts are delayed by a saturated host: Got: \"%s\"", i, elapsed)
+		}
+		for range results {
+		}
+	}
+}
+
+func TestCheckerRate(t *testing.T) {
+	client := urltest.NewHTTPClient()
+	urls := []string{"https://boratanrikulu.dev/a", "https://boratanrikulu.dev/b", "https://boratanrikulu.dev/c", "https://seo.do/a"}
+	for _, rawurl := range urls {
+		client.Handle(rawurl, urltest.Response{})
+	}
+
+	var testValues = []struct {
+		Checker    Checker
+		WantedTime time.Duration
+	}{
+		// 4 checks with 50 checks per second take 60ms at least.
+		{Checker: Checker{Rate: 50}, WantedTime: 60 * time.Millisecond},
+		// 3 checks of a domain with 25 checks per second take 80ms at least.
+		{Checker: Checker{DomainRate: 25}, WantedTime: 80 * time.Millisecond},
+	}
+
+	for _, testValue := range testValues {
+		c := testValue.Checker
+		c.Checks, c.LiveOptions = CheckHTTP, LiveOptions{Client: client}
+
+		start := time.Now()
+		for range c.CheckAll(context.Background(), urls) {
+		}
+		if elapsed := time.Since(start); elapsed < testValue.WantedTime {
+			t.Fatalf("Checks are not rate limited: Wanted: \"%s\" - Got: \"%s\"", testValue.WantedTime, elapsed)
+		}
+	}
+}
+
+func TestCheckerCancel(t *testing.T) {
+	client := urltest.NewHTTPClient()
+	client.Handle("https://boratanrikulu.dev", urltest.Response{Delay: time.Second})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	input := make(chan string)
+	go func() {
+		for {
+			select {
+			case input <- "https://boratanrikulu.dev":
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	c := &Checker{Checks: CheckHTTP, LiveOptions: LiveOptions{Client: client}, Workers: 2}
+	results := c.Check(ctx, input)
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	done := make(chan struct{})
+	go func() {
+		for result := range results {
+			if result.Err != nil && !errors.Is(result.Err, context.Canceled) {
+				t.Errorf("Error is wrong: Wanted: \"%v\" - Got: \"%v\"", context.Canceled, result.Err)
+			}
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatalf("Results channel is not closed after the cancellation")
+	}
+}
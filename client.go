@@ -0,0 +1,29 @@
+package url
+
+import (
+	"net"
+	nethttp "net/http"
+)
+
+// HTTPClient sends HTTP requests. *http.Client implements it.
+// A client that is not a *http.Client must not follow the redirects itself,
+// since the checks follow them to record the chain.
+type HTTPClient interface {
+	Do(req *nethttp.Request) (*nethttp.Response, error)
+}
+
+var (
+	// DefaultResolver is the resolver that LookupDNS and IsRecorded use
+	// when a resolver is not given.
+	DefaultResolver Resolver = net.DefaultResolver
+
+	// DefaultHTTPClient is the client that CheckLive, TraceRedirects and IsLive
+	// use when a client is not given.
+	//
+	// Both of them can be replaced, e.g. by the fakes of the urltest package,
+	// so the checks can run without network:
+	//
+	// url.DefaultResolver = urltest.NewResolver()
+	// url.DefaultHTTPClient = urltest.NewHTTPClient()
+	DefaultHTTPClient HTTPClient = &nethttp.Client{}
+)
@@ -0,0 +1,277 @@
+package url
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultDNSTimeout is the timeout of a lookup if it is not given.
+const defaultDNSTimeout = 5 * time.Second
+
+// Resolver looks up the DNS records of a host.
+// *net.Resolver implements it, so net.DefaultResolver or a resolver with a
+// custom Dial can be used as it is.
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+	LookupCNAME(ctx context.Context, host string) (string, error)
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupNS(ctx context.Context, name string) ([]*net.NS, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// RecordType selects the types of the DNS records to look up.
+// The types can be combined with "|", e.g. RecordA | RecordAAAA.
+type RecordType uint
+
+const (
+	RecordA RecordType = 1 << iota
+	RecordAAAA
+	RecordCNAME
+	RecordMX
+	RecordNS
+	RecordTXT
+
+	// RecordAll includes all the types.
+	RecordAll = RecordA | RecordAAAA | RecordCNAME | RecordMX | RecordNS | RecordTXT
+)
+
+// DNSStatus is the outcome of a lookup.
+type DNSStatus string
+
+const (
+	// DNSNoError means the host has records of the looked up types.
+	DNSNoError DNSStatus = "NOERROR"
+	// DNSNXDomain means the host does not exist.
+	DNSNXDomain DNSStatus = "NXDOMAIN"
+	// DNSNoData means the host exists, but it does not have any records of
+	// the looked up types, e.g. a host without NS records.
+	DNSNoData DNSStatus = "NODATA"
+	// DNSServFail means the name server could not answer.
+	DNSServFail DNSStatus = "SERVFAIL"
+	// DNSTimeout means the lookup timed out.
+	DNSTimeout DNSStatus = "TIMEOUT"
+	// DNSCanceled means the context of the lookup is canceled.
+	DNSCanceled DNSStatus = "CANCELED"
+	// DNSError means the lookup failed for another reason.
+	DNSError DNSStatus = "ERROR"
+)
+
+// DNSOptions configures LookupDNS.
+type DNSOptions struct {
+	// Resolver looks up the records. It is DefaultResolver by default.
+	Resolver Resolver
+	// Types are the types of the records to look up. It is RecordAll by default.
+	Types RecordType
+	// Timeout is the timeout of the lookup. It is 5 seconds by default.
+	Timeout time.Duration
+	// Cache caches the results of the lookups if it is given. The results are
+	// kept per Resolver, so a Cache can be shared by different resolvers.
+	Cache *Cache
+}
+
+// DNSResult is the result of LookupDNS.
+type DNSResult struct {
+	Host string
+	// Recorded tells whether the host has any records of the looked up types.
+	Recorded bool
+	Status   DNSStatus
+	A        []net.IP
+	AAAA     []net.IP
+	// CNAME is the canonical name of the host, if the host is an alias.
+	CNAME string
+	MX    []*net.MX
+	NS    []*net.NS
+	TXT   []string
+	// Err is the error of the lookup that decides the status, if any.
+	Err error
+}
+
+// LookupDNS looks up the DNS records of the URL's host. The records of the
+// types are looked up concurrently, and the lookup can be canceled by the context.
+//
+// Example Usage:
+//
+// u, _ := NewURL("https://boratanrikulu.dev")
+// result := u.LookupDNS(ctx, DNSOptions{Types: RecordA | RecordMX})
+// fmt.Println(result.Status) // "NOERROR"
+// fmt.Println(result.A)      // [185.199.108.153 ...]
+func (u *URL) LookupDNS(ctx context.Context, opts DNSOptions) DNSResult {
+	resolver := opts.Resolver
+	if resolver == nil {
+		resolver = DefaultResolver
+	}
+	types := opts.Types
+	if types == 0 {
+		types = RecordAll
+	}
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = defaultDNSTimeout
+	}
+
+	if opts.Cache != nil {
+		if result, ok := opts.Cache.getDNS(resolver, u.FullDomain, types); ok {
+			return result
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	result := lookupDNS(ctx, resolver, u.FullDomain, types)
+	if opts.Cache != nil {
+		opts.Cache.setDNS(resolver, u.FullDomain, types, result)
+	}
+	return result
+}
+
+// lookupDNS looks up the records of the types of the host concurrently.
+func lookupDNS(ctx context.Context, resolver Resolver, host string, types RecordType) DNSResult {
+	result := DNSResult{Host: host}
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	lookup := func(f func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := f(); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	if types&(RecordA|RecordAAAA) != 0 {
+		lookup(func() error {
+			addrs, err := resolver.LookupIPAddr(ctx, result.Host)
+			for _, addr := range addrs {
+				if ip4 := addr.IP.To4(); ip4 != nil {
+					if types&RecordA != 0 {
+						result.A = append(result.A, ip4)
+					}
+				} else if types&RecordAAAA != 0 {
+					result.AAAA = append(result.AAAA, addr.IP)
+				}
+			}
+			return err
+		})
+	}
+	if types&RecordCNAME != 0 {
+		lookup(func() error {
+			cname, err := resolver.LookupCNAME(ctx, result.Host)
+			if cname = strings.TrimSuffix(cname, "."); err == nil && !strings.EqualFold(cname, result.Host) {
+				result.CNAME = cname
+			}
+			return err
+		})
+	}
+	if types&RecordMX != 0 {
+		lookup(func() (err error) {
+			result.MX, err = resolver.LookupMX(ctx, result.Host)
+			return err
+		})
+	}
+	if types&RecordNS != 0 {
+		lookup(func() (err error) {
+			result.NS, err = resolver.LookupNS(ctx, result.Host)
+			return err
+		})
+	}
+	if types&RecordTXT != 0 {
+		lookup(func() (err error) {
+			result.TXT, err = resolver.LookupTXT(ctx, result.Host)
+			return err
+		})
+	}
+	wg.Wait()
+
+	result.Recorded = len(result.A) > 0 || len(result.AAAA) > 0 || result.CNAME != "" ||
+		len(result.MX) > 0 || len(result.NS) > 0 || len(result.TXT) > 0
+	if result.Recorded {
+		result.Status = DNSNoError
+		return result
+	}
+	result.Status, result.Err = dnsStatus(ctx, errs)
+	if result.Status == DNSNXDomain && (result.Err == nil || hostExists(ctx, resolver, result.Host, types)) {
+		// The lookups are answered without an error, or the host has records
+		// of other types, so only the looked up types are missing.
+		result.Status = DNSNoData
+	}
+	return result
+}
+
+// hostExists tells whether the host has any records of the types that are
+// not looked up. *net.Resolver reports both NXDOMAIN and NODATA as "not
+// found", so they are told apart by the other types. LookupCNAME finds the
+// hosts that have A or AAAA records as well.
+func hostExists(ctx context.Context, resolver Resolver, host string, types RecordType) bool {
+	if types&(RecordA|RecordAAAA|RecordCNAME) != RecordA|RecordAAAA|RecordCNAME {
+		if cname, err := resolver.LookupCNAME(ctx, host); err == nil && cname != "" {
+			return true
+		}
+	}
+	if types&RecordMX == 0 {
+		if mx, err := resolver.LookupMX(ctx, host); err == nil && len(mx) > 0 {
+			return true
+		}
+	}
+	if types&RecordNS == 0 {
+		if ns, err := resolver.LookupNS(ctx, host); err == nil && len(ns) > 0 {
+			return true
+		}
+	}
+	if types&RecordTXT == 0 {
+		if txt, err := resolver.LookupTXT(ctx, host); err == nil && len(txt) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// dnsStatus returns the status of a lookup that did not find any records
+// and the error that decides it. The errors are ranked as timeouts,
+// server failures and then the others, since "not found" is the least
+// informative one.
+func dnsStatus(ctx context.Context, errs []error) (DNSStatus, error) {
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return DNSCanceled, ctx.Err()
+	}
+
+	status, decisive := DNSNXDomain, error(nil)
+	rank := map[DNSStatus]int{DNSNXDomain: 0, DNSError: 1, DNSServFail: 2, DNSTimeout: 3, DNSCanceled: 4}
+	for _, err := range errs {
+		s := classifyDNSError(err)
+		if decisive == nil || rank[s] > rank[status] {
+			status, decisive = s, err
+		}
+	}
+	return status, decisive
+}
+
+// classifyDNSError returns the status of the error of a lookup.
+func classifyDNSError(err error) DNSStatus {
+	var dnsErr *net.DNSError
+	switch {
+	case errors.Is(err, context.Canceled):
+		return DNSCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return DNSTimeout
+	case errors.As(err, &dnsErr):
+		switch {
+		case dnsErr.IsTimeout:
+			return DNSTimeout
+		case dnsErr.IsNotFound:
+			return DNSNXDomain
+		case dnsErr.IsTemporary:
+			return DNSServFail
+		}
+	}
+	return DNSError
+}
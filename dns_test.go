@@ -0,0 +1,149 @@
+package url
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+// fakeResolver answers the lookups from its maps. The hosts that are not in
+// the maps are not found, and the hosts in errs fail with their errors.
+type fakeResolver struct {
+	ips    map[string][]net.IPAddr
+	cnames map[string]string
+	mxs    map[string][]*net.MX
+	txts   map[string][]string
+	errs   map[string]error
+	delay  time.Duration
+}
+
+func (r *fakeResolver) lookup(ctx context.Context, host string, found bool) error {
+	if r.delay > 0 {
+		select {
+		case <-ctx.Done():
+			return &net.DNSError{Err: ctx.Err().Error(), Name: host, IsTimeout: true}
+		case <-time.After(r.delay):
+		}
+	}
+	if err, ok := r.errs[host]; ok {
+		return err
+	}
+	if !found {
+		return &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return nil
+}
+
+func (r *fakeResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	ips, ok := r.ips[host]
+	return ips, r.lookup(ctx, host, ok)
+}
+
+func (r *fakeResolver) LookupCNAME(ctx context.Context, host string) (string, error) {
+	cname, ok := r.cnames[host]
+	if !ok {
+		_, ok = r.ips[host]
+		cname = host + "."
+	}
+	return cname, r.lookup(ctx, host, ok)
+}
+
+func (r *fakeResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	mxs, ok := r.mxs[name]
+	return mxs, r.lookup(ctx, name, ok)
+}
+
+func (r *fakeResolver) LookupNS(ctx context.Context, name string) ([]*net.NS, error) {
+	return nil, r.lookup(ctx, name, false)
+}
+
+func (r *fakeResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	txts, ok := r.txts[name]
+	return txts, r.lookup(ctx, name, ok)
+}
+
+func TestLookupDNS(t *testing.T) {
+	resolver := &fakeResolver{
+		ips: map[string][]net.IPAddr{
+			"boratanrikulu.dev":     {{IP: net.ParseIP("185.199.108.153")}, {IP: net.ParseIP("2606:50c0:8000::153")}},
+			"www.boratanrikulu.dev": {{IP: net.ParseIP("185.199.108.153")}},
+		},
+		cnames: map[string]string{"www.boratanrikulu.dev": "boratanrikulu.github.io."},
+		mxs:    map[string][]*net.MX{"boratanrikulu.dev": {{Host: "mx.example.com.", Pref: 10}}},
+		txts:   map[string][]string{"boratanrikulu.dev": {"v=spf1 -all"}},
+		errs: map[string]error{
+			"broken.boratanrikulu.dev": &net.DNSError{Err: "server misbehaving", Name: "broken.boratanrikulu.dev", IsTemporary: true},
+		},
+	}
+
+	var testValues = []struct {
+		Input          string
+		Types          RecordType
+		WantedStatus   DNSStatus
+		WantedRecorded bool
+		WantedA        int
+		WantedAAAA     int
+		WantedCNAME    string
+		WantedMX       int
+		WantedTXT      int
+	}{
+		{Input: "https://boratanrikulu.dev", WantedStatus: DNSNoError, WantedRecorded: true, WantedA: 1, WantedAAAA: 1, WantedMX: 1, WantedTXT: 1},
+		{Input: "https://boratanrikulu.dev", Types: RecordA, WantedStatus: DNSNoError, WantedRecorded: true, WantedA: 1},
+		{Input: "https://boratanrikulu.dev", Types: RecordNS, WantedStatus: DNSNoData},
+		{Input: "https://www.boratanrikulu.dev", Types: RecordMX | RecordTXT, WantedStatus: DNSNoData},
+		{Input: "https://nope.boratanrikulu.dev", Types: RecordNS, WantedStatus: DNSNXDomain},
+		{Input: "https://www.boratanrikulu.dev", WantedStatus: DNSNoError, WantedRecorded: true, WantedA: 1, WantedCNAME: "boratanrikulu.github.io"},
+		{Input: "https://nope.boratanrikulu.dev", WantedStatus: DNSNXDomain},
+		{Input: "https://broken.boratanrikulu.dev", WantedStatus: DNSServFail},
+	}
+
+	for _, testValue := range testValues {
+		u, err := NewURL(testValue.Input)
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Input)
+		}
+
+		result := u.LookupDNS(context.Background(), DNSOptions{Resolver: resolver, Types: testValue.Types})
+		if result.Status != testValue.WantedStatus {
+			t.Fatalf("[%s] Status is wrong: Wanted: \"%s\" - Got: \"%s\" (%v)", testValue.Input, testValue.WantedStatus, result.Status, result.Err)
+		}
+		if result.Recorded != testValue.WantedRecorded {
+			t.Fatalf("[%s] Recorded is wrong: Wanted: \"%t\" - Got: \"%t\"", testValue.Input, testValue.WantedRecorded, result.Recorded)
+		}
+		wanted := []int{testValue.WantedA, testValue.WantedAAAA, testValue.WantedMX, testValue.WantedTXT}
+		got := []int{len(result.A), len(result.AAAA), len(result.MX), len(result.TXT)}
+		for i := range wanted {
+			if wanted[i] != got[i] {
+				t.Fatalf("[%s] Records are wrong: Wanted: %v - Got: %v", testValue.Input, wanted, got)
+			}
+		}
+		if result.CNAME != testValue.WantedCNAME {
+			t.Fatalf("[%s] CNAME is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Input, testValue.WantedCNAME, result.CNAME)
+		}
+		if result.Status != DNSNoError && result.Status != DNSNXDomain && result.Status != DNSNoData && result.Err == nil {
+			t.Fatalf("[%s] Err must be set for the status %s", testValue.Input, result.Status)
+		}
+	}
+}
+
+func TestLookupDNSContext(t *testing.T) {
+	resolver := &fakeResolver{delay: time.Second}
+	u, err := NewURL("https://boratanrikulu.dev")
+	if err != nil {
+		t.Fatalf("Error occur: %s", err)
+	}
+
+	result := u.LookupDNS(context.Background(), DNSOptions{Resolver: resolver, Timeout: 20 * time.Millisecond})
+	if result.Status != DNSTimeout {
+		t.Fatalf("Status is wrong: Wanted: \"%s\" - Got: \"%s\" (%v)", DNSTimeout, result.Status, result.Err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result = u.LookupDNS(ctx, DNSOptions{Resolver: resolver})
+	if result.Status != DNSCanceled || !errors.Is(result.Err, context.Canceled) {
+		t.Fatalf("Status is wrong: Wanted: \"%s\" - Got: \"%s\" (%v)", DNSCanceled, result.Status, result.Err)
+	}
+}
@@ -0,0 +1,115 @@
+package url
+
+import "strings"
+
+// RegistrableDomain returns the registrable domain of the URL, which is its
+// public suffix with one more label (eTLD+1), e.g. "boratanrikulu.com.tr" for
+// "https://blog.boratanrikulu.com.tr". It is the part of the host that can
+// be registered by someone.
+// An empty string is returned if the host is an IP address.
+func (u *URL) RegistrableDomain() string {
+	if u.HostType != HostDomain || u.Domain == "" {
+		return ""
+	}
+	// A special-use domain name may not have a suffix, e.g. "localhost".
+	if suffix := u.PublicSuffix(); suffix != "" {
+		return u.Domain + "." + suffix
+	}
+	return u.Domain
+}
+
+// PublicSuffix returns the public suffix (eTLD) of the URL, e.g. "com.tr" for
+// "https://blog.boratanrikulu.com.tr". Unlike Suffix, it is assembled from
+// the current values of TLD and CTLD.
+// An empty string is returned if the host is an IP address.
+func (u *URL) PublicSuffix() string {
+	switch {
+	case u.HostType != HostDomain:
+		return ""
+	case u.CTLD == "":
+		return u.TLD
+	case u.TLD == "":
+		return u.CTLD
+	}
+	return u.TLD + "." + u.CTLD
+}
+
+// IsSubdomainOf returns whether the host of the URL is a subdomain of the host
+// of the other URL. A host is not a subdomain of itself, and IP addresses do
+// not have any subdomains.
+//
+// Example Usage:
+//
+// u, _ := NewURL("https://an.awesome.blog.boratanrikulu.dev")
+// other, _ := NewURL("https://blog.boratanrikulu.dev")
+// fmt.Println(u.IsSubdomainOf(other)) // true
+// fmt.Println(other.IsSubdomainOf(u)) // false
+func (u *URL) IsSubdomainOf(other *URL) bool {
+	if u.HostType != HostDomain || other.HostType != HostDomain {
+		return false
+	}
+	return strings.HasSuffix(u.hostname(), "."+other.hostname())
+}
+
+// SameRegistrableDomain returns whether the URLs have the same registrable
+// domain, e.g. "https://blog.boratanrikulu.dev" and "http://boratanrikulu.dev".
+// It is false if any of the hosts is an IP address.
+func (u *URL) SameRegistrableDomain(other *URL) bool {
+	domain := u.RegistrableDomain()
+	return domain != "" && domain == other.RegistrableDomain()
+}
+
+// ParentDomains returns the ancestors of the host of the URL from the nearest
+// one up to the registrable domain. The host itself is not included.
+//
+// Example Usage:
+//
+// u, _ := NewURL("https://an.awesome.blog.boratanrikulu.com.tr")
+// fmt.Println(u.ParentDomains()) // "[awesome.blog.boratanrikulu.com.tr blog.boratanrikulu.com.tr boratanrikulu.com.tr]"
+func (u *URL) ParentDomains() []string {
+	if u.HostType != HostDomain || len(u.Subdomains) == 0 {
+		return []string{}
+	}
+
+	host := u.hostname()
+	parents := make([]string, 0, len(u.Subdomains))
+	for _, label := range u.Subdomains {
+		host = host[len(label)+1:]
+		parents = append(parents, host)
+	}
+	return parents
+}
+
+// SameOrigin returns whether the URLs have the same origin by the definition
+// of the HTML standard: the same scheme, host and port. The default port of
+// the scheme is used if the port is not given.
+//
+// Only the URLs with the http, https, ws, wss and ftp schemes have a tuple
+// origin. The other URLs, e.g. "file:" ones, have an opaque origin that is
+// not the same origin with any other URL.
+// source: https://html.spec.whatwg.org/multipage/browsers.html#same-origin
+//
+// Example Usage:
+//
+// u, _ := NewURL("https://boratanrikulu.dev/blog")
+// other, _ := NewURL("https://boratanrikulu.dev:443/about")
+// fmt.Println(u.SameOrigin(other)) // true
+func (u *URL) SameOrigin(other *URL) bool {
+	scheme := strings.ToLower(u.Scheme)
+	if !tupleOriginSchemes[scheme] || scheme != strings.ToLower(other.Scheme) {
+		return false
+	}
+	return u.HostType == other.HostType &&
+		u.hostname() == other.hostname() &&
+		u.PortOrDefault() == other.PortOrDefault()
+}
+
+// tupleOriginSchemes includes the schemes whose URLs have a tuple origin.
+// source: https://url.spec.whatwg.org/#origin
+var tupleOriginSchemes = map[string]bool{
+	"ftp":   true,
+	"http":  true,
+	"https": true,
+	"ws":    true,
+	"wss":   true,
+}
@@ -0,0 +1,108 @@
+package url
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRegistrableDomain(t *testing.T) {
+	var testValues = []struct {
+		Input             string
+		WantedRegistrable string
+		WantedSuffix      string
+		WantedParents     []string
+	}{
+		{
+			Input:             "https://boratanrikulu.dev",
+			WantedRegistrable: "boratanrikulu.dev",
+			WantedSuffix:      "dev",
+			WantedParents:     []string{},
+		},
+		{
+			Input:             "https://an.awesome.blog.boratanrikulu.com.tr/blog",
+			WantedRegistrable: "boratanrikulu.com.tr",
+			WantedSuffix:      "com.tr",
+			WantedParents:     []string{"awesome.blog.boratanrikulu.com.tr", "blog.boratanrikulu.com.tr", "boratanrikulu.com.tr"},
+		},
+		{
+			Input:             "https://foo.github.io/bar",
+			WantedRegistrable: "foo.github.io",
+			WantedSuffix:      "github.io",
+			WantedParents:     []string{},
+		},
+		{
+			Input:             "https://www.bar.blogspot.co.uk",
+			WantedRegistrable: "bar.blogspot.co.uk",
+			WantedSuffix:      "blogspot.co.uk",
+			WantedParents:     []string{"bar.blogspot.co.uk"},
+		},
+		{
+			Input:             "https://192.168.1.10/admin",
+			WantedRegistrable: "",
+			WantedSuffix:      "",
+			WantedParents:     []string{},
+		},
+	}
+
+	for _, testValue := range testValues {
+		u, err := NewURL(testValue.Input)
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Input)
+		}
+
+		if got := u.RegistrableDomain(); got != testValue.WantedRegistrable {
+			t.Fatalf("[%s] RegistrableDomain is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Input, testValue.WantedRegistrable, got)
+		}
+		if got := u.PublicSuffix(); got != testValue.WantedSuffix {
+			t.Fatalf("[%s] PublicSuffix is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Input, testValue.WantedSuffix, got)
+		}
+		if got := u.ParentDomains(); !reflect.DeepEqual(got, testValue.WantedParents) {
+			t.Fatalf("[%s] ParentDomains are wrong: Wanted: %q - Got: %q", testValue.Input, testValue.WantedParents, got)
+		}
+	}
+}
+
+func TestDomainRelations(t *testing.T) {
+	var testValues = []struct {
+		Input                 string
+		Other                 string
+		WantedSubdomain       bool
+		WantedSameRegistrable bool
+		WantedSameOrigin      bool
+	}{
+		{Input: "https://blog.boratanrikulu.dev", Other: "https://boratanrikulu.dev", WantedSubdomain: true, WantedSameRegistrable: true},
+		{Input: "https://boratanrikulu.dev", Other: "https://blog.boratanrikulu.dev", WantedSameRegistrable: true},
+		{Input: "https://boratanrikulu.dev/blog", Other: "https://boratanrikulu.dev:443/about?q=a", WantedSameRegistrable: true, WantedSameOrigin: true},
+		{Input: "HTTPS://boratanrikulu.dev", Other: "https://BORATANRIKULU.dev", WantedSameRegistrable: true, WantedSameOrigin: true},
+		{Input: "http://boratanrikulu.dev", Other: "https://boratanrikulu.dev", WantedSameRegistrable: true},
+		{Input: "https://boratanrikulu.dev:8443", Other: "https://boratanrikulu.dev", WantedSameRegistrable: true},
+		{Input: "https://myboratanrikulu.dev", Other: "https://boratanrikulu.dev"},
+		{Input: "https://foo.github.io", Other: "https://bar.github.io"},
+		{Input: "https://foo.github.io", Other: "https://github.io.seo.do"},
+		{Input: "https://a.b.boratanrikulu.com.tr", Other: "https://b.boratanrikulu.com.tr", WantedSubdomain: true, WantedSameRegistrable: true},
+		{Input: "https://192.168.1.10/admin", Other: "https://192.168.1.10/login", WantedSameOrigin: true},
+		{Input: "http://[2001:db8::1]:80/", Other: "http://[2001:DB8::1]/", WantedSameOrigin: true},
+		{Input: "mailto://boratanrikulu.dev", Other: "mailto://boratanrikulu.dev", WantedSameRegistrable: true},
+	}
+
+	for _, testValue := range testValues {
+		u, err := NewURL(testValue.Input)
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Input)
+		}
+		other, err := NewURL(testValue.Other)
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Other)
+		}
+
+		if got := u.IsSubdomainOf(other); got != testValue.WantedSubdomain {
+			t.Fatalf("[%s %s] IsSubdomainOf is wrong: Wanted: \"%t\" - Got: \"%t\"", testValue.Input, testValue.Other, testValue.WantedSubdomain, got)
+		}
+		if got := u.SameRegistrableDomain(other); got != testValue.WantedSameRegistrable {
+			t.Fatalf("[%s %s] SameRegistrableDomain is wrong: Wanted: \"%t\" - Got: \"%t\"", testValue.Input, testValue.Other, testValue.WantedSameRegistrable, got)
+		}
+		if got := u.SameOrigin(other); got != testValue.WantedSameOrigin {
+			t.Fatalf("[%s %s] SameOrigin is wrong: Wanted: \"%t\" - Got: \"%t\"", testValue.Input, testValue.Other, testValue.WantedSameOrigin, got)
+		}
+	}
+}
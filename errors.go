@@ -0,0 +1,135 @@
+package url
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Reason is the code of the reason that a URL is not valid.
+// It can be used to group the rejected URLs by their causes.
+type Reason string
+
+const (
+	// ReasonMalformed is for a URL that can not be parsed at all.
+	ReasonMalformed Reason = "malformed"
+	// ReasonMissingScheme is for a URL without a scheme, e.g. "boratanrikulu.dev".
+	ReasonMissingScheme Reason = "missing-scheme"
+	// ReasonMissingHost is for a URL without a host, e.g. "https:///blog".
+	ReasonMissingHost Reason = "missing-host"
+	// ReasonInvalidHost is for a host that is not a valid domain name, e.g. an invalid IDN.
+	ReasonInvalidHost Reason = "invalid-host"
+	// ReasonSingleLabelHost is for a host with a single label, e.g. "https://boratanrikulu".
+	ReasonSingleLabelHost Reason = "single-label-host"
+	// ReasonUnknownSuffix is for a host whose suffix is not on the list, e.g. "boratanrikulu.randomwrongtld".
+	ReasonUnknownSuffix Reason = "unknown-suffix"
+	// ReasonPublicSuffix is for a host that is a public suffix itself, e.g. "https://co.uk".
+	ReasonPublicSuffix Reason = "public-suffix"
+	// ReasonEmptyLabel is for a host with an empty label, e.g. "https://.com".
+	ReasonEmptyLabel Reason = "empty-label"
+	// ReasonLabelTooLong is for a host with a label that is longer than 63 octets.
+	ReasonLabelTooLong Reason = "label-too-long"
+	// ReasonHostTooLong is for a host that is longer than 253 octets.
+	ReasonHostTooLong Reason = "host-too-long"
+	// ReasonLeadingHyphen is for a host with a label that starts with a hyphen, e.g. "https://-bad-.com".
+	ReasonLeadingHyphen Reason = "leading-hyphen"
+	// ReasonTrailingHyphen is for a host with a label that ends with a hyphen, e.g. "https://bad-.com".
+	ReasonTrailingHyphen Reason = "trailing-hyphen"
+	// ReasonInvalidCharacter is for a host with a character that is not a letter,
+	// a digit or a hyphen, e.g. "https://my_site.com".
+	ReasonInvalidCharacter Reason = "invalid-character"
+	// ReasonTrailingDot is for a host that ends with the root dot, e.g. "https://example.com.".
+	ReasonTrailingDot Reason = "trailing-dot"
+	// ReasonSpecialUse is for a special-use domain name that the Parser rejects,
+	// e.g. "http://localhost:8080".
+	ReasonSpecialUse Reason = "special-use"
+)
+
+// The errors that a ParseError wraps for each reason.
+// They can be checked by errors.Is.
+var (
+	ErrMalformed        = errors.New("The URL can not be parsed.")
+	ErrMissingScheme    = errors.New("The URL does not have a scheme.")
+	ErrMissingHost      = errors.New("The URL does not have a host.")
+	ErrInvalidHost      = errors.New("The host is not a valid domain name.")
+	ErrSingleLabelHost  = errors.New("The host has a single label.")
+	ErrUnknownSuffix    = errors.New("The suffix of the host is not on the list.")
+	ErrPublicSuffix     = errors.New("The host is a public suffix.")
+	ErrEmptyLabel       = errors.New("The host has an empty label.")
+	ErrLabelTooLong     = errors.New("A label of the host is longer than 63 characters.")
+	ErrHostTooLong      = errors.New("The host is longer than 253 characters.")
+	ErrLeadingHyphen    = errors.New("A label of the host starts with a hyphen.")
+	ErrTrailingHyphen   = errors.New("A label of the host ends with a hyphen.")
+	ErrInvalidCharacter = errors.New("The host has a character that is not allowed.")
+	ErrTrailingDot      = errors.New("The host ends with a dot.")
+	ErrSpecialUse       = errors.New("The host is a special-use domain name.")
+)
+
+// reasonErrors maps the reasons to the errors that they are wrapped with.
+var reasonErrors = map[Reason]error{
+	ReasonMalformed:        ErrMalformed,
+	ReasonMissingScheme:    ErrMissingScheme,
+	ReasonMissingHost:      ErrMissingHost,
+	ReasonInvalidHost:      ErrInvalidHost,
+	ReasonSingleLabelHost:  ErrSingleLabelHost,
+	ReasonUnknownSuffix:    ErrUnknownSuffix,
+	ReasonPublicSuffix:     ErrPublicSuffix,
+	ReasonEmptyLabel:       ErrEmptyLabel,
+	ReasonLabelTooLong:     ErrLabelTooLong,
+	ReasonHostTooLong:      ErrHostTooLong,
+	ReasonLeadingHyphen:    ErrLeadingHyphen,
+	ReasonTrailingHyphen:   ErrTrailingHyphen,
+	ReasonInvalidCharacter: ErrInvalidCharacter,
+	ReasonTrailingDot:      ErrTrailingDot,
+	ReasonSpecialUse:       ErrSpecialUse,
+}
+
+// The components of a URL that a ParseError can be about.
+const (
+	ComponentURL    = "url"
+	ComponentScheme = "scheme"
+	ComponentHost   = "host"
+	ComponentSuffix = "suffix"
+)
+
+// ParseError is the error that is returned when a URL is not valid.
+//
+// Example Usage:
+//
+//	_, err := NewURL("https://boratanrikulu.randomwrongtld")
+//	errors.Is(err, ErrUnknownSuffix) // true
+//
+//	var parseErr *ParseError
+//	if errors.As(err, &parseErr) {
+//		fmt.Println(parseErr.Reason) // "unknown-suffix"
+//	}
+type ParseError struct {
+	// Input is the URL that is given.
+	Input string
+	// Component is the part of the URL that is not valid, e.g. ComponentHost.
+	Component string
+	// Reason is the code of the reason.
+	Reason Reason
+}
+
+// newParseError returns a new ParseError.
+func newParseError(input, component string, reason Reason) *ParseError {
+	return &ParseError{
+		Input:     input,
+		Component: component,
+		Reason:    reason,
+	}
+}
+
+// Error returns the error message with the given URL.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("That's not a valid URL: %q. %s", e.Input, e.Unwrap())
+}
+
+// Unwrap returns the error of the reason, so the ParseError can be checked
+// by errors.Is, e.g. errors.Is(err, ErrMissingScheme).
+func (e *ParseError) Unwrap() error {
+	if err, ok := reasonErrors[e.Reason]; ok {
+		return err
+	}
+	return ErrMalformed
+}
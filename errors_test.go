@@ -0,0 +1,146 @@
+package url
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	var testValues = []struct {
+		Input           string
+		WantedComponent string
+		WantedReason    Reason
+		WantedErr       error
+	}{
+		{
+			Input:           "",
+			WantedComponent: ComponentScheme,
+			WantedReason:    ReasonMissingScheme,
+			WantedErr:       ErrMissingScheme,
+		},
+		{
+			Input:           "boratanrikulu.dev/blog",
+			WantedComponent: ComponentScheme,
+			WantedReason:    ReasonMissingScheme,
+			WantedErr:       ErrMissingScheme,
+		},
+		{
+			Input:           "https://bora tanrikulu.dev",
+			WantedComponent: ComponentURL,
+			WantedReason:    ReasonMalformed,
+			WantedErr:       ErrMalformed,
+		},
+		{
+			Input:           "https:///blog",
+			WantedComponent: ComponentHost,
+			WantedReason:    ReasonMissingHost,
+			WantedErr:       ErrMissingHost,
+		},
+		{
+			Input:           "https://boratanrikulu",
+			WantedComponent: ComponentHost,
+			WantedReason:    ReasonSingleLabelHost,
+			WantedErr:       ErrSingleLabelHost,
+		},
+		{
+			Input:           "https://boratanrikulu.randomwrongtld",
+			WantedComponent: ComponentSuffix,
+			WantedReason:    ReasonUnknownSuffix,
+			WantedErr:       ErrUnknownSuffix,
+		},
+		{
+			Input:           "https://co.uk",
+			WantedComponent: ComponentHost,
+			WantedReason:    ReasonPublicSuffix,
+			WantedErr:       ErrPublicSuffix,
+		},
+		{
+			Input:           "https://xn--a.de",
+			WantedComponent: ComponentHost,
+			WantedReason:    ReasonInvalidHost,
+			WantedErr:       ErrInvalidHost,
+		},
+		{
+			Input:           "https://.com",
+			WantedComponent: ComponentHost,
+			WantedReason:    ReasonEmptyLabel,
+			WantedErr:       ErrEmptyLabel,
+		},
+		{
+			Input:           "https://www..boratanrikulu.dev",
+			WantedComponent: ComponentHost,
+			WantedReason:    ReasonEmptyLabel,
+			WantedErr:       ErrEmptyLabel,
+		},
+		{
+			Input:           "https://xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx.com",
+			WantedComponent: ComponentHost,
+			WantedReason:    ReasonLabelTooLong,
+			WantedErr:       ErrLabelTooLong,
+		},
+		{
+			Input:           "https://abcdefghi.abcdefghi.abcdefghi.abcdefghi.abcdefghi.abcdefghi.abcdefghi.abcdefghi.abcdefghi.abcdefghi.abcdefghi.abcdefghi.abcdefghi.abcdefghi.abcdefghi.abcdefghi.abcdefghi.abcdefghi.abcdefghi.abcdefghi.abcdefghi.abcdefghi.abcdefghi.abcdefghi.abcdefghi.abcdefghi.com",
+			WantedComponent: ComponentHost,
+			WantedReason:    ReasonHostTooLong,
+			WantedErr:       ErrHostTooLong,
+		},
+		{
+			Input:           "https://-bad-.com",
+			WantedComponent: ComponentHost,
+			WantedReason:    ReasonLeadingHyphen,
+			WantedErr:       ErrLeadingHyphen,
+		},
+		{
+			Input:           "https://bad-.com",
+			WantedComponent: ComponentHost,
+			WantedReason:    ReasonTrailingHyphen,
+			WantedErr:       ErrTrailingHyphen,
+		},
+		{
+			Input:           "https://my_site.com",
+			WantedComponent: ComponentHost,
+			WantedReason:    ReasonInvalidCharacter,
+			WantedErr:       ErrInvalidCharacter,
+		},
+		{
+			Input:           "https://a*b.com",
+			WantedComponent: ComponentHost,
+			WantedReason:    ReasonInvalidCharacter,
+			WantedErr:       ErrInvalidCharacter,
+		},
+		{
+			Input:           "https://boratanrikulu.dev.",
+			WantedComponent: ComponentHost,
+			WantedReason:    ReasonTrailingDot,
+			WantedErr:       ErrTrailingDot,
+		},
+	}
+
+	for _, testValue := range testValues {
+		_, err := NewURL(testValue.Input)
+		if err == nil {
+			t.Fatalf("[%s] Error must be occurred, but did not", testValue.Input)
+		}
+
+		if !errors.Is(err, testValue.WantedErr) {
+			t.Fatalf("[%s] Error is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Input, testValue.WantedErr, err)
+		}
+
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("[%s] Error must be a ParseError: Got: %T", testValue.Input, err)
+		}
+
+		if parseErr.Input != testValue.Input {
+			t.Fatalf("[%s] Input is wrong: Got: \"%s\"", testValue.Input, parseErr.Input)
+		}
+
+		if parseErr.Component != testValue.WantedComponent {
+			t.Fatalf("[%s] Component is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Input, testValue.WantedComponent, parseErr.Component)
+		}
+
+		if parseErr.Reason != testValue.WantedReason {
+			t.Fatalf("[%s] Reason is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Input, testValue.WantedReason, parseErr.Reason)
+		}
+	}
+}
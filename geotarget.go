@@ -0,0 +1,105 @@
+package url
+
+import "strings"
+
+// GeoGeneric is the geotarget of the domains that are not targeted to a
+// country, e.g. the ones under "com", "io" or "eu".
+const GeoGeneric = "generic"
+
+// geoTargets maps the public suffixes to their geotargets, which are the
+// ISO 3166-1 alpha-2 codes of the countries or GeoGeneric. It keeps the
+// exceptions only: the country-code TLDs that the search engines treat as
+// generic ones, and the second-level suffixes that are targeted to a country
+// under them, e.g. "com.co".
+//
+// The suffixes that are not in the table are targeted by their TLDs, so
+// "com.tr" and "co.uk" are targeted to "TR" and "GB", and "com" is generic.
+// The entries can be overridden by WithGeoTargets.
+//
+// source: https://developers.google.com/search/docs/specialty/international/managing-multi-regional-sites
+var geoTargets = map[string]string{
+	"ad": GeoGeneric,
+	"ai": GeoGeneric,
+	"as": GeoGeneric,
+	"bz": GeoGeneric,
+	"cc": GeoGeneric,
+	"cd": GeoGeneric,
+	"co": GeoGeneric,
+	"dj": GeoGeneric,
+	"fm": GeoGeneric,
+	"io": GeoGeneric,
+	"la": GeoGeneric,
+	"me": GeoGeneric,
+	"ms": GeoGeneric,
+	"nu": GeoGeneric,
+	"sc": GeoGeneric,
+	"sr": GeoGeneric,
+	"su": GeoGeneric,
+	"tk": GeoGeneric,
+	"tv": GeoGeneric,
+	"ws": GeoGeneric,
+
+	// The regional TLDs are treated as generic ones, e.g. "eu" and its
+	// Cyrillic and Greek forms "ею" and "ευ".
+	"eu":        GeoGeneric,
+	"xn--e1a4c": GeoGeneric,
+	"xn--qxa6a": GeoGeneric,
+
+	// The second-level suffixes of the generic country-code TLDs that are
+	// only registered in the country.
+	"com.co": "CO",
+	"edu.co": "CO",
+	"gov.co": "CO",
+	"mil.co": "CO",
+	"net.co": "CO",
+	"org.co": "CO",
+	"edu.me": "ME",
+	"gov.me": "ME",
+}
+
+// GeoTarget returns the country that the domain of the URL is targeted to
+// for the search engines, as an ISO 3166-1 alpha-2 code, or GeoGeneric if
+// it is not targeted to any country. The public suffix is looked up in the
+// table of the Parser, see WithGeoTargets, from its longest form to its TLD,
+// e.g. "com.co" and then "co", and the country of the TLD is used if it is
+// not found.
+// An empty string is returned if the host is an IP address or its TLD is
+// not delegated, e.g. "printer.local".
+//
+// Example Usage:
+//
+// u, _ := NewURL("https://an.awesome.blog.boratanrikulu.com.tr")
+// fmt.Println(u.GeoTarget()) // "TR"
+//
+// u, _ = NewURL("https://boratanrikulu.io")
+// fmt.Println(u.GeoTarget()) // "generic"
+func (u *URL) GeoTarget() string {
+	suffix := u.PublicSuffix()
+	if suffix == "" {
+		return ""
+	}
+
+	targets := u.geoTargets
+	if targets == nil {
+		targets = geoTargets
+	}
+	for name := suffix; ; {
+		if target, ok := targets[name]; ok {
+			return target
+		}
+		i := strings.IndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		name = name[i+1:]
+	}
+
+	info, ok := TLDInfo(suffix[strings.LastIndex(suffix, ".")+1:])
+	switch {
+	case !ok || info.Status != TLDDelegated:
+		return ""
+	case info.Category == TLDCountryCode:
+		return info.CountryCode
+	}
+	return GeoGeneric
+}
@@ -0,0 +1,76 @@
+package url
+
+import "testing"
+
+func TestGeoTarget(t *testing.T) {
+	var testValues = []struct {
+		Input  string
+		Wanted string
+	}{
+		{Input: "https://boratanrikulu.de", Wanted: "DE"},
+		{Input: "https://an.awesome.blog.boratanrikulu.com.tr", Wanted: "TR"},
+		{Input: "https://www.bbc.co.uk", Wanted: "GB"},
+		{Input: "https://www.bar.blogspot.co.uk", Wanted: "GB"},
+		{Input: "https://пример.рф", Wanted: "RU"},
+		{Input: "https://boratanrikulu.dev", Wanted: GeoGeneric},
+		{Input: "https://boratanrikulu.com", Wanted: GeoGeneric},
+		{Input: "https://boratanrikulu.co", Wanted: GeoGeneric},
+		{Input: "https://boratanrikulu.com.co", Wanted: "CO"},
+		{Input: "https://boratanrikulu.io", Wanted: GeoGeneric},
+		{Input: "https://boratanrikulu.tv", Wanted: GeoGeneric},
+		{Input: "https://boratanrikulu.me", Wanted: GeoGeneric},
+		{Input: "https://boratanrikulu.ai", Wanted: GeoGeneric},
+		{Input: "https://boratanrikulu.eu", Wanted: GeoGeneric},
+		{Input: "https://foo.github.io", Wanted: GeoGeneric},
+		{Input: "https://192.168.1.10/admin", Wanted: ""},
+		{Input: "http://printer.local", Wanted: ""},
+		{Input: "http://localhost:8080", Wanted: ""},
+	}
+
+	for _, testValue := range testValues {
+		u, err := NewURL(testValue.Input)
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Input)
+		}
+
+		if got := u.GeoTarget(); got != testValue.Wanted {
+			t.Fatalf("[%s] GeoTarget is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Input, testValue.Wanted, got)
+		}
+	}
+}
+
+func TestGeoTargetOverride(t *testing.T) {
+	table := map[string]string{"io": "IO", "COM.TR.": GeoGeneric}
+	p := NewParser(WithGeoTargets(table))
+	// The Parser keeps a copy of the table.
+	table["gen.tr"] = GeoGeneric
+
+	var testValues = []struct {
+		Input        string
+		Wanted       string
+		WantedParser string
+	}{
+		{Input: "https://boratanrikulu.io", Wanted: GeoGeneric, WantedParser: "IO"},
+		{Input: "https://boratanrikulu.com.tr", Wanted: "TR", WantedParser: GeoGeneric},
+		{Input: "https://boratanrikulu.gen.tr", Wanted: "TR", WantedParser: "TR"},
+		{Input: "https://boratanrikulu.com.co", Wanted: "CO", WantedParser: "CO"},
+	}
+
+	for _, testValue := range testValues {
+		u, err := NewURL(testValue.Input)
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Input)
+		}
+		if got := u.GeoTarget(); got != testValue.Wanted {
+			t.Fatalf("[%s] GeoTarget is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Input, testValue.Wanted, got)
+		}
+
+		u, err = p.Parse(testValue.Input)
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Input)
+		}
+		if got := u.GeoTarget(); got != testValue.WantedParser {
+			t.Fatalf("[%s] GeoTarget of the Parser is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Input, testValue.WantedParser, got)
+		}
+	}
+}
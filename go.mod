@@ -1,3 +1,5 @@
 module github.com/zeoagency/url
 
 go 1.15
+
+require golang.org/x/net v0.17.0
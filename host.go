@@ -0,0 +1,117 @@
+package url
+
+import (
+	"net"
+	"strings"
+)
+
+// HostType is the type of the host of a URL.
+type HostType int
+
+const (
+	// HostDomain is a host that is a domain name, e.g. "boratanrikulu.dev".
+	HostDomain HostType = iota
+	// HostIPv4 is a host that is an IPv4 address, e.g. "192.168.1.10".
+	HostIPv4
+	// HostIPv6 is a host that is an IPv6 address, e.g. "[2001:db8::1]".
+	HostIPv6
+)
+
+// String returns the name of the host type.
+func (t HostType) String() string {
+	switch t {
+	case HostIPv4:
+		return "IPv4"
+	case HostIPv6:
+		return "IPv6"
+	}
+	return "domain"
+}
+
+// parseIPHost returns the type of the host and the host in lower case if the
+// host is an IP address. An IPv6 address may have a zone ID, e.g. "fe80::1%en0".
+// HostDomain is returned if the host is not an IP address.
+func parseIPHost(host string) (HostType, string) {
+	addr := host
+	if i := strings.LastIndex(host, "%"); i >= 0 && strings.Contains(host, ":") {
+		addr = host[:i]
+	}
+
+	switch {
+	case net.ParseIP(addr) == nil:
+		return HostDomain, ""
+	case !strings.Contains(addr, ":"):
+		return HostIPv4, host
+	}
+	return HostIPv6, strings.ToLower(addr) + host[len(addr):]
+}
+
+// The limits of a domain name in its ASCII form.
+// source: https://tools.ietf.org/html/rfc1035#section-2.3.4
+const (
+	maxLabelLength = 63
+	maxHostLength  = 253
+)
+
+// HostPolicy relaxes the validation of the hosts that are not strictly valid.
+// The policies can be combined with "|", e.g. AllowUnderscore | AllowTrailingDot.
+type HostPolicy uint
+
+const (
+	// AllowUnderscore accepts the underscores in the labels, which are common
+	// in DNS service records, e.g. "_dmarc.example.com".
+	AllowUnderscore HostPolicy = 1 << iota
+	// AllowTrailingDot accepts a host that ends with the root dot, e.g.
+	// "example.com.". The dot is removed from the host.
+	AllowTrailingDot
+	// AllowEmptyLabels accepts the empty labels, e.g. "www..example.com".
+	// They are removed from the host.
+	AllowEmptyLabels
+)
+
+// validateLabels validates the labels of the host by the LDH rule, so each
+// label only has letters, digits and hyphens, and does not start or end
+// with a hyphen. The labels are returned without the empty ones that the
+// policy allows.
+// source: https://tools.ietf.org/html/rfc1123#section-2.1
+func validateLabels(labels []string, policy HostPolicy) ([]string, Reason) {
+	if n := len(labels); n > 1 && labels[n-1] == "" {
+		if policy&AllowTrailingDot == 0 {
+			return nil, ReasonTrailingDot
+		}
+		labels = labels[:n-1]
+	}
+
+	length, kept := len(labels)-1, labels[:0]
+	for _, label := range labels {
+		if label == "" {
+			if policy&AllowEmptyLabels == 0 {
+				return nil, ReasonEmptyLabel
+			}
+			length--
+			continue
+		}
+		if len(label) > maxLabelLength {
+			return nil, ReasonLabelTooLong
+		}
+		if label[0] == '-' {
+			return nil, ReasonLeadingHyphen
+		}
+		if label[len(label)-1] == '-' {
+			return nil, ReasonTrailingHyphen
+		}
+		for i := 0; i < len(label); i++ {
+			c := label[i]
+			if !('a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' && policy&AllowUnderscore != 0) {
+				return nil, ReasonInvalidCharacter
+			}
+		}
+		length += len(label)
+		kept = append(kept, label)
+	}
+
+	if length > maxHostLength {
+		return nil, ReasonHostTooLong
+	}
+	return kept, ""
+}
@@ -0,0 +1,93 @@
+package url
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// acePrefix is the prefix of the A-labels, the ASCII forms of the IDN labels.
+const acePrefix = "xn--"
+
+// errInvalidIDN is returned when a host is not a valid IDN.
+var errInvalidIDN = errors.New("That's not a valid IDN label.")
+
+// idnaProfile maps and validates the hosts by UTS-46 for lookup: the hosts
+// are mapped by the UTS-46 table (e.g. full-width "ｅ" to "e", "Ⅻ" to "xii"
+// and the soft hyphen to nothing) and normalized by NFC, and the labels are
+// checked by the rules of RFC 5891 (e.g. a label can not start with a
+// combining mark), the CONTEXTJ rules of RFC 5892 and the Bidi rule of
+// RFC 5893. The A-labels are validated in the same way after they are decoded.
+//
+// The hyphens and the ASCII characters out of the LDH rule, such as "_", are
+// left to the HostPolicy of the Parser.
+//
+// source: https://www.unicode.org/reports/tr46/#Processing
+var idnaProfile = idna.New(
+	idna.MapForLookup(),
+	idna.BidiRule(),
+	idna.Transitional(false),
+	idna.StrictDomainName(false),
+	idna.CheckHyphens(false),
+)
+
+// toASCII returns the ASCII form of the host by converting each of its
+// Unicode labels to an A-label (e.g. "münchen.de" to "xn--mnchen-3ya.de")
+// after the host is processed by UTS-46, see idnaProfile.
+func toASCII(host string) (string, error) {
+	if lower := strings.ToLower(host); isASCII(lower) && !strings.Contains(lower, acePrefix) {
+		return lower, nil
+	}
+
+	// The A-labels are checked before and after the mapping, since the
+	// mapping drops the empty ones, e.g. "xn--".
+	if !validALabels(strings.ToLower(host)) {
+		return "", errInvalidIDN
+	}
+	ascii, err := idnaProfile.ToASCII(host)
+	if err != nil || !validALabels(ascii) {
+		return "", errInvalidIDN
+	}
+	return ascii, nil
+}
+
+// validALabels tells whether each of the A-labels of the host stands for a
+// label that is not ASCII, e.g. "xn--" and "xn--abc-" are not valid.
+func validALabels(host string) bool {
+	for _, label := range strings.Split(host, ".") {
+		if !strings.HasPrefix(label, acePrefix) {
+			continue
+		}
+		if decoded, err := idnaProfile.ToUnicode(label); err != nil || isASCII(decoded) {
+			return false
+		}
+	}
+	return true
+}
+
+// toUnicode returns the Unicode form of the ASCII host by converting each of
+// its A-labels back (e.g. "xn--mnchen-3ya.de" to "münchen.de").
+// The host is returned as it is if it does not have any A-label.
+func toUnicode(host string) (string, error) {
+	if !strings.Contains(host, acePrefix) {
+		return host, nil
+	}
+
+	unicode, err := idnaProfile.ToUnicode(host)
+	if err != nil {
+		return "", errInvalidIDN
+	}
+	return unicode, nil
+}
+
+// isASCII tells whether s only has ASCII characters.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,174 @@
+package url
+
+import "testing"
+
+func TestToUnicode(t *testing.T) {
+	// source: https://tools.ietf.org/html/rfc3492#section-7.1
+	var testValues = []struct {
+		Unicode string
+		ASCII   string
+	}{
+		{Unicode: "münchen", ASCII: "xn--mnchen-3ya"},
+		{Unicode: "bücher.de", ASCII: "xn--bcher-kva.de"},
+		{Unicode: "中国", ASCII: "xn--fiqs8s"},
+		{Unicode: "рф", ASCII: "xn--p1ai"},
+		{Unicode: "他们为什么不说中文", ASCII: "xn--ihqwcrb4cv8a8dqg056pqjye"},
+		{Unicode: "そのスピードで", ASCII: "xn--d9juau41awczczp"},
+		{Unicode: "boratanrikulu.dev", ASCII: "boratanrikulu.dev"},
+	}
+
+	for _, testValue := range testValues {
+		ascii, err := toASCII(testValue.Unicode)
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Unicode)
+		}
+		if ascii != testValue.ASCII {
+			t.Fatalf("[%s] ASCII form is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Unicode, testValue.ASCII, ascii)
+		}
+
+		unicode, err := toUnicode(testValue.ASCII)
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.ASCII)
+		}
+		if unicode != testValue.Unicode {
+			t.Fatalf("[%s] Unicode form is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.ASCII, testValue.Unicode, unicode)
+		}
+	}
+}
+
+func TestToASCII(t *testing.T) {
+	var testValues = []struct {
+		Input      string
+		Wanted     string
+		ShouldFail bool
+	}{
+		{Input: "boratanrikulu.dev", Wanted: "boratanrikulu.dev"},
+		{Input: "BoraTanrikulu.DEV", Wanted: "boratanrikulu.dev"},
+		{Input: "münchen.de", Wanted: "xn--mnchen-3ya.de"},
+		{Input: "MÜNCHEN.DE", Wanted: "xn--mnchen-3ya.de"},
+		{Input: "xn--mnchen-3ya.de", Wanted: "xn--mnchen-3ya.de"},
+		{Input: "XN--MNCHEN-3YA.de", Wanted: "xn--mnchen-3ya.de"},
+		{Input: "пример.рф", Wanted: "xn--e1afmkfd.xn--p1ai"},
+		{Input: "例子。中国", Wanted: "xn--fsqu00a.xn--fiqs8s"},
+		{Input: "ｅｘａｍｐｌｅ．ｃｏｍ", Wanted: "example.com"},
+		// "u" with a combining diaeresis is normalized to "ü" by NFC.
+		{Input: "mu\u0308nchen.de", Wanted: "xn--mnchen-3ya.de"},
+		{Input: "Ⅻ.com", Wanted: "xii.com"},
+		{Input: "ﬁle.com", Wanted: "file.com"},
+		// The A-label of the decomposed "münchen" is not in NFC.
+		{Input: "xn--munchen-gie.de", ShouldFail: true},
+		// The soft hyphen is mapped to nothing by UTS-46.
+		{Input: "ex\u00ADample.com", Wanted: "example.com"},
+		{Input: "_dmarc.münchen.de", Wanted: "_dmarc.xn--mnchen-3ya.de"},
+		// A label can not start with a combining mark.
+		// source: https://tools.ietf.org/html/rfc5891#section-4.2.3.2
+		{Input: "\u0301abc.de", ShouldFail: true},
+		// A zero width joiner must follow a virama.
+		// source: https://tools.ietf.org/html/rfc5892#appendix-A.2
+		{Input: "a\u200Db.com", ShouldFail: true},
+		// A label can not mix right-to-left and left-to-right characters.
+		// source: https://tools.ietf.org/html/rfc5893#section-2
+		{Input: "aא.com", ShouldFail: true},
+		{Input: "xn--a.de", ShouldFail: true},
+		{Input: "xn--abc.de", ShouldFail: true},
+		{Input: "xn--.de", ShouldFail: true},
+		{Input: "xn--abc-.de", ShouldFail: true},
+		{Input: "mün\uFFFDchen.de", ShouldFail: true},
+	}
+
+	for _, testValue := range testValues {
+		got, err := toASCII(testValue.Input)
+		if testValue.ShouldFail {
+			if err == nil {
+				t.Fatalf("[%s] Error must be occurred, but did not", testValue.Input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Input)
+		}
+		if got != testValue.Wanted {
+			t.Fatalf("[%s] ASCII form is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Input, testValue.Wanted, got)
+		}
+	}
+}
+
+func TestNewURLIDN(t *testing.T) {
+	var testValues = []struct {
+		Input                   string
+		WantedDomain            string
+		WantedSuffix            string
+		WantedFullDomain        string
+		WantedUnicodeDomain     string
+		WantedUnicodeSuffix     string
+		WantedUnicodeFullDomain string
+	}{
+		{
+			Input:                   "https://www.münchen.de/stadt",
+			WantedDomain:            "xn--mnchen-3ya",
+			WantedSuffix:            "de",
+			WantedFullDomain:        "www.xn--mnchen-3ya.de",
+			WantedUnicodeDomain:     "münchen",
+			WantedUnicodeSuffix:     "de",
+			WantedUnicodeFullDomain: "www.münchen.de",
+		},
+		{
+			Input:                   "https://www.mu\u0308nchen.de/stadt",
+			WantedDomain:            "xn--mnchen-3ya",
+			WantedSuffix:            "de",
+			WantedFullDomain:        "www.xn--mnchen-3ya.de",
+			WantedUnicodeDomain:     "münchen",
+			WantedUnicodeSuffix:     "de",
+			WantedUnicodeFullDomain: "www.münchen.de",
+		},
+		{
+			Input:                   "https://xn--mnchen-3ya.de",
+			WantedDomain:            "xn--mnchen-3ya",
+			WantedSuffix:            "de",
+			WantedFullDomain:        "xn--mnchen-3ya.de",
+			WantedUnicodeDomain:     "münchen",
+			WantedUnicodeSuffix:     "de",
+			WantedUnicodeFullDomain: "münchen.de",
+		},
+		{
+			Input:                   "https://пример.рф",
+			WantedDomain:            "xn--e1afmkfd",
+			WantedSuffix:            "xn--p1ai",
+			WantedFullDomain:        "xn--e1afmkfd.xn--p1ai",
+			WantedUnicodeDomain:     "пример",
+			WantedUnicodeSuffix:     "рф",
+			WantedUnicodeFullDomain: "пример.рф",
+		},
+		{
+			Input:                   "https://例子．中国",
+			WantedDomain:            "xn--fsqu00a",
+			WantedSuffix:            "xn--fiqs8s",
+			WantedFullDomain:        "xn--fsqu00a.xn--fiqs8s",
+			WantedUnicodeDomain:     "例子",
+			WantedUnicodeSuffix:     "中国",
+			WantedUnicodeFullDomain: "例子.中国",
+		},
+		{
+			Input:                   "https://boratanrikulu.dev",
+			WantedDomain:            "boratanrikulu",
+			WantedSuffix:            "dev",
+			WantedFullDomain:        "boratanrikulu.dev",
+			WantedUnicodeDomain:     "boratanrikulu",
+			WantedUnicodeSuffix:     "dev",
+			WantedUnicodeFullDomain: "boratanrikulu.dev",
+		},
+	}
+
+	for _, testValue := range testValues {
+		u, err := NewURL(testValue.Input)
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Input)
+		}
+
+		got := []string{u.Domain, u.Suffix, u.FullDomain, u.UnicodeDomain, u.UnicodeSuffix, u.UnicodeFullDomain}
+		wanted := []string{testValue.WantedDomain, testValue.WantedSuffix, testValue.WantedFullDomain, testValue.WantedUnicodeDomain, testValue.WantedUnicodeSuffix, testValue.WantedUnicodeFullDomain}
+		if !equalStringSlice(wanted, got) {
+			t.Fatalf("[%s] Host parts are wrong: Wanted: %q - Got: %q", testValue.Input, wanted, got)
+		}
+	}
+}
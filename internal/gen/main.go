@@ -0,0 +1,275 @@
+// Command gen generates the suffix tables of the url package.
+//
+// It reads a locally supplied IANA list (tlds-alpha-by-domain.txt) and/or
+// a Public Suffix List (public_suffix_list.dat) and writes a Go file that
+// keeps the TLDs, the country-code TLDs, the suffix rules and the versions
+// and the dates of the given lists. The registry operators of the generic
+// TLDs and the countries of the IDN country-code TLDs are taken from the
+// comments of the Public Suffix List.
+//
+// Example Usage:
+//
+//	go run ./internal/gen -iana data/tlds-alpha-by-domain.txt -psl data/public_suffix_list.dat -output tables.go
+//
+// When the IANA list is not given, the TLDs are taken from the single-label
+// rules of the ICANN section of the Public Suffix List. When the Public Suffix
+// List is not given, each TLD of the IANA list becomes an ICANN rule.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/zeoagency/url/internal/iana"
+	"golang.org/x/net/idna"
+)
+
+func main() {
+	ianaPath := flag.String("iana", "", "path of the IANA tlds-alpha-by-domain.txt")
+	pslPath := flag.String("psl", "", "path of the public_suffix_list.dat")
+	pslVersion := flag.String("psl-version", "", "version of the Public Suffix List if the list does not have a VERSION header")
+	output := flag.String("output", "tables.go", "path of the generated file")
+	flag.Parse()
+
+	if *ianaPath == "" && *pslPath == "" {
+		log.Fatalln("At least one of -iana and -psl must be given.")
+	}
+
+	var t tables
+	if *pslPath != "" {
+		f, err := os.Open(*pslPath)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		err = t.readPSL(f)
+		f.Close()
+		if err != nil {
+			log.Fatalln(err)
+		}
+		if t.pslVersion == "" {
+			t.pslVersion = *pslVersion
+		}
+	}
+	if *ianaPath != "" {
+		f, err := os.Open(*ianaPath)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		err = t.readIANA(f)
+		f.Close()
+		if err != nil {
+			log.Fatalln(err)
+		}
+	}
+	if *pslPath == "" {
+		t.icannRules = t.tlds
+	}
+
+	src, err := t.generate()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+// tables keeps everything that is written to the generated file.
+type tables struct {
+	ianaVersion  string
+	ianaUpdated  string
+	pslVersion   string
+	tlds         []string
+	icannRules   []string
+	privateRules []string
+
+	// operators maps the generic TLDs to their registry operators, and
+	// idnCountries maps the IDN country-code TLDs to their country codes.
+	operators    map[string]string
+	idnCountries map[string]string
+}
+
+// The comments of the Public Suffix List that are written above the TLDs,
+// e.g. "// aaa : 2015-02-26 American Automobile Association, Inc." and
+// "// xn--p1ai ("rf", Russian-Cyrillic) : RU".
+var (
+	operatorComment   = regexp.MustCompile(`^// [a-z0-9-]+ : \d{4}-\d{2}-\d{2} (.+)$`)
+	idnCountryComment = regexp.MustCompile(`^// xn--[a-z0-9-]+ .*: ([A-Z]{2})$`)
+)
+
+// readIANA reads the TLDs from an IANA list.
+//
+// The list starts with a version line such as
+// "# Version 2021012800, Last Updated Thu Jan 28 07:07:02 2021 UTC".
+// The IDN TLDs of the list are A-labels, e.g. "XN--P1AI", and they are
+// kept as U-labels, e.g. "рф", like the rules of the Public Suffix List.
+func (t *tables) readIANA(r io.Reader) error {
+	t.tlds = nil
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			if t.ianaVersion == "" {
+				t.ianaVersion, t.ianaUpdated = iana.ParseVersion(line)
+			}
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		tld, err := idna.ToUnicode(strings.ToLower(line))
+		if err != nil {
+			return fmt.Errorf("%s: %v", line, err)
+		}
+		t.tlds = append(t.tlds, tld)
+	}
+	return scanner.Err()
+}
+
+// readPSL reads the ICANN and private rules from a Public Suffix List.
+//
+// The version is taken from the "// VERSION:" and "// COMMIT:" lines of the
+// list if it has them.
+func (t *tables) readPSL(r io.Reader) error {
+	var version, commit string
+	var section *[]string
+	// operator and country are taken from a comment for the rule that follows it.
+	var operator, country string
+	t.operators = make(map[string]string)
+	t.idnCountries = make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if m := operatorComment.FindStringSubmatch(line); m != nil {
+			operator = strings.TrimSpace(m[1])
+		} else if m := idnCountryComment.FindStringSubmatch(line); m != nil {
+			country = m[1]
+		}
+
+		switch {
+		case strings.HasPrefix(line, "// VERSION:"):
+			version = strings.TrimSpace(strings.TrimPrefix(line, "// VERSION:"))
+		case strings.HasPrefix(line, "// COMMIT:"):
+			commit = strings.TrimSpace(strings.TrimPrefix(line, "// COMMIT:"))
+		case strings.HasPrefix(line, "// ===BEGIN ICANN DOMAINS==="):
+			section = &t.icannRules
+		case strings.HasPrefix(line, "// ===BEGIN PRIVATE DOMAINS==="):
+			section = &t.privateRules
+		case strings.HasPrefix(line, "// ===END"):
+			section = nil
+		case line == "" || strings.HasPrefix(line, "//") || section == nil:
+		default:
+			// Only the first field of a line is the rule.
+			rule := strings.ToLower(strings.Fields(line)[0])
+			*section = append(*section, rule)
+			if operator != "" && section == &t.icannRules {
+				t.operators[rule] = operator
+			}
+			if country != "" {
+				t.idnCountries[rule] = country
+			}
+			operator, country = "", ""
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	t.pslVersion = version
+	if commit != "" {
+		t.pslVersion = strings.TrimSpace(version + " " + commit)
+	}
+	if len(t.icannRules) == 0 {
+		return errors.New("There is no ICANN rule in the Public Suffix List.")
+	}
+
+	// Take the TLDs from the list in case the IANA list is not given.
+	// Some TLDs only have wildcard rules (e.g. "*.ck"), so the last label
+	// of each rule is used.
+	t.tlds = nil
+	seen := make(map[string]bool)
+	for _, rule := range t.icannRules {
+		tld := rule[strings.LastIndex(rule, ".")+1:]
+		if !seen[tld] {
+			seen[tld] = true
+			t.tlds = append(t.tlds, tld)
+		}
+	}
+	return nil
+}
+
+// generate returns the formatted source of the generated file.
+func (t *tables) generate() ([]byte, error) {
+	var generic, country []string
+	for _, tld := range t.tlds {
+		if iana.IsCountryCode(tld) {
+			country = append(country, tld)
+		} else {
+			generic = append(generic, tld)
+		}
+	}
+	sort.Strings(generic)
+	sort.Strings(country)
+
+	var b bytes.Buffer
+	fmt.Fprintln(&b, "// Code generated by internal/gen; DO NOT EDIT.")
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "package url")
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "// ianaVersion is the version of the IANA list that the tables were generated from.")
+	fmt.Fprintf(&b, "const ianaVersion = %q\n", t.ianaVersion)
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "// ianaUpdated is the \"Last Updated\" date of the IANA list that the tables were generated from.")
+	fmt.Fprintf(&b, "const ianaUpdated = %q\n", t.ianaUpdated)
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "// pslVersion is the version of the Public Suffix List that the tables were generated from.")
+	fmt.Fprintf(&b, "const pslVersion = %q\n", t.pslVersion)
+	writeSlice(&b, "countryTopLevelDomains includes all country-code-top-level-domains.", "countryTopLevelDomains", country)
+	writeSlice(&b, "topLevelDomains includes all top-level-domains except the country-code ones.", "topLevelDomains", generic)
+	writeSlice(&b, "icannSuffixes includes the rules from the ICANN section of the Public Suffix List.", "icannSuffixes", t.icannRules)
+	writeSlice(&b, "privateSuffixes includes the rules from the PRIVATE section of the Public Suffix List.", "privateSuffixes", t.privateRules)
+	writeMap(&b, "tldOperators maps the generic TLDs to their registry operators.", "tldOperators", t.operators)
+	writeMap(&b, "idnCountryTopLevelDomains maps the IDN country-code TLDs to their ISO 3166 country codes.", "idnCountryTopLevelDomains", t.idnCountries)
+
+	return format.Source(b.Bytes())
+}
+
+// writeSlice writes a string slice variable with the given doc comment.
+func writeSlice(b *bytes.Buffer, doc, name string, values []string) {
+	fmt.Fprintln(b)
+	fmt.Fprintf(b, "// %s\n", doc)
+	fmt.Fprintf(b, "var %s = []string{\n", name)
+	for _, v := range values {
+		fmt.Fprintf(b, "\t%q,\n", v)
+	}
+	fmt.Fprintln(b, "}")
+}
+
+// writeMap writes a string map variable with the given doc comment.
+// The keys are sorted, so the output is stable.
+func writeMap(b *bytes.Buffer, doc, name string, values map[string]string) {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintln(b)
+	fmt.Fprintf(b, "// %s\n", doc)
+	fmt.Fprintf(b, "var %s = map[string]string{\n", name)
+	for _, k := range keys {
+		fmt.Fprintf(b, "\t%q: %q,\n", k, values[k])
+	}
+	fmt.Fprintln(b, "}")
+}
@@ -0,0 +1,35 @@
+// Package iana reads the IANA list of the TLDs (tlds-alpha-by-domain.txt) for
+// both the url package and its table generator.
+package iana
+
+import "strings"
+
+// ParseVersion returns the version number and the "Last Updated" date of the
+// version line of an IANA list, e.g. "2021012800" and "Thu Jan 28 07:07:02 2021 UTC"
+// of "# Version 2021012800, Last Updated Thu Jan 28 07:07:02 2021 UTC".
+// Both are empty if the line is not a version line.
+func ParseVersion(line string) (version, updated string) {
+	fields := strings.Fields(strings.TrimPrefix(line, "#"))
+	if len(fields) < 2 || fields[0] != "Version" {
+		return "", ""
+	}
+	version = strings.TrimSuffix(fields[1], ",")
+	if len(fields) > 4 && fields[2] == "Last" && fields[3] == "Updated" {
+		updated = strings.Join(fields[4:], " ")
+	}
+	return version, updated
+}
+
+// IsCountryCode tells whether the TLD is a country-code TLD, which are the
+// two letter ASCII ones.
+func IsCountryCode(tld string) bool {
+	if len(tld) != 2 {
+		return false
+	}
+	for _, c := range tld {
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
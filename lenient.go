@@ -0,0 +1,160 @@
+package url
+
+import "strings"
+
+// Repair is a fix that ParseLenient applied to its input.
+type Repair string
+
+const (
+	// RepairTrimSpace means the whitespace around the URL is removed.
+	RepairTrimSpace Repair = "trim-space"
+	// RepairUnwrap means the quotes or the angle brackets around the URL are
+	// removed, e.g. "<https://example.com>" or "\"https://example.com\"".
+	RepairUnwrap Repair = "unwrap"
+	// RepairAddScheme means the default scheme is added to the URL that does
+	// not have a scheme, e.g. "example.com/page".
+	RepairAddScheme Repair = "add-scheme"
+	// RepairSchemeRelative means the default scheme is added to the
+	// scheme-relative URL, e.g. "//example.com/page".
+	RepairSchemeRelative Repair = "scheme-relative"
+)
+
+// lenientWrappers includes the pairs that URLs are wrapped with when they are
+// pasted from texts, mails and code.
+var lenientWrappers = [][2]string{
+	{"<", ">"},
+	{"\"", "\""},
+	{"'", "'"},
+	{"`", "`"},
+	{"“", "”"},
+	{"‘", "’"},
+}
+
+// ParseLenient parses the messy URL that NewURL rejects, such as
+// "www.example.co.uk" or " <HTTPS://Example.COM> ", by repairing it first.
+// It returns the repairs it applied in their order.
+// It is a shorthand for DefaultParser().ParseLenient(rawurl).
+//
+// Example Usage:
+//
+// u, repairs, _ := ParseLenient(" \"boratanrikulu.dev/blog\" ")
+// fmt.Println(u.String()) // "https://boratanrikulu.dev/blog"
+// fmt.Println(repairs)    // [trim-space unwrap add-scheme]
+func ParseLenient(rawurl string) (*URL, []Repair, error) {
+	return defaultParser.ParseLenient(rawurl)
+}
+
+// ParseLenient repairs the URL and parses it.
+// The whitespace and the wrappers around the URL are removed, and the default
+// scheme of the Parser is added if the URL does not have a scheme.
+// A host with a port, e.g. "localhost:8080", is not taken as a scheme.
+func (p *Parser) ParseLenient(rawurl string) (*URL, []Repair, error) {
+	var repairs []Repair
+	s := rawurl
+	for {
+		if trimmed := strings.TrimSpace(s); trimmed != s {
+			s = trimmed
+			repairs = appendRepair(repairs, RepairTrimSpace)
+		}
+		unwrapped := unwrap(s)
+		if unwrapped == s {
+			break
+		}
+		s = unwrapped
+		repairs = appendRepair(repairs, RepairUnwrap)
+	}
+
+	switch {
+	case strings.HasPrefix(s, "//"):
+		s = p.defaultScheme + ":" + s
+		repairs = append(repairs, RepairSchemeRelative)
+	case !hasScheme(s):
+		s = p.defaultScheme + "://" + s
+		repairs = append(repairs, RepairAddScheme)
+	}
+
+	u, err := p.Parse(s)
+	if err != nil {
+		return nil, repairs, err
+	}
+	return u, repairs, nil
+}
+
+// appendRepair appends the repair unless it is already applied.
+func appendRepair(repairs []Repair, repair Repair) []Repair {
+	for _, r := range repairs {
+		if r == repair {
+			return repairs
+		}
+	}
+	return append(repairs, repair)
+}
+
+// unwrap removes one of the wrappers around s.
+// The "URL:" prefix in the angle brackets is removed as well.
+// source: https://tools.ietf.org/html/rfc3986#appendix-C
+func unwrap(s string) string {
+	for _, w := range lenientWrappers {
+		if len(s) < len(w[0])+len(w[1]) || !strings.HasPrefix(s, w[0]) || !strings.HasSuffix(s, w[1]) {
+			continue
+		}
+		s = s[len(w[0]) : len(s)-len(w[1])]
+		if w[0] == "<" && len(s) >= 4 && strings.EqualFold(s[:4], "URL:") {
+			s = s[4:]
+		}
+		return s
+	}
+	return s
+}
+
+// userSchemes are the schemes whose URLs are in the "scheme:user@host" form.
+var userSchemes = map[string]bool{
+	"mailto": true,
+	"sip":    true,
+	"sips":   true,
+	"xmpp":   true,
+}
+
+// hasScheme tells whether s starts with a scheme.
+// source: https://tools.ietf.org/html/rfc3986#section-3.1
+func hasScheme(s string) bool {
+	i := strings.Index(s, ":")
+	if i <= 0 {
+		return false
+	}
+	for j := 0; j < i; j++ {
+		c := s[j]
+		switch {
+		case 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z':
+		case j > 0 && ('0' <= c && c <= '9' || c == '+' || c == '-' || c == '.'):
+		default:
+			return false
+		}
+	}
+
+	// It is a user and a password if an "@" comes before the path and the
+	// authority does not start with "//", e.g. "user:pass@example.com",
+	// unless the scheme is one of the schemes that address a user so.
+	rest := s[i+1:]
+	if !strings.HasPrefix(rest, "//") && !userSchemes[strings.ToLower(s[:i])] {
+		at := strings.IndexByte(s, '@')
+		if slash := strings.IndexByte(s, '/'); at >= 0 && (slash < 0 || at < slash) {
+			return false
+		}
+	}
+
+	// It is a port if it only has digits, e.g. "example.com:8080/page".
+	end := strings.IndexAny(rest, "/?#")
+	if end < 0 {
+		end = len(rest)
+	}
+	if end == 0 {
+		return true
+	}
+	for j := 0; j < end; j++ {
+		if rest[j] < '0' || rest[j] > '9' {
+			return true
+		}
+	}
+	return false
+}
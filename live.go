@@ -0,0 +1,415 @@
+package url
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	nethttp "net/http"
+	neturl "net/url"
+	"time"
+)
+
+const (
+	// DefaultUserAgent is the User-Agent that the checks send if it is not given.
+	DefaultUserAgent = "zeoagency-url (+https://github.com/zeoagency/url)"
+
+	// defaultLiveTimeout is the timeout of a request if it is not given.
+	defaultLiveTimeout = 5 * time.Second
+	// defaultMaxRedirects is the number of the redirects that are followed
+	// if it is not given.
+	defaultMaxRedirects = 10
+	// maxDrainedBody is the number of the bytes that are read from a body
+	// before it is closed, so the connection can be reused.
+	maxDrainedBody = 4 << 10
+)
+
+var (
+	// ErrTooManyRedirects is returned when the number of the redirects is more
+	// than the limit.
+	ErrTooManyRedirects = errors.New("There are too many redirects.")
+	// ErrUnacceptableStatus is returned when the final status code is not in
+	// the acceptable ranges.
+	ErrUnacceptableStatus = errors.New("The status code is not acceptable.")
+)
+
+// ErrorClass is the class of the error that a check fails with.
+type ErrorClass string
+
+const (
+	// ErrorClassNone means the check did not fail.
+	ErrorClassNone ErrorClass = ""
+	// ErrorClassDNS means the host could not be resolved. It is a temporary
+	// failure unless the host is not found, see IsNotFound of *net.DNSError.
+	// The DNS timeouts are ErrorClassTimeout.
+	ErrorClassDNS ErrorClass = "dns"
+	// ErrorClassConnection means the connection could not be made, e.g. it is refused or reset.
+	ErrorClassConnection ErrorClass = "connection"
+	// ErrorClassTLS means the TLS handshake failed, e.g. the certificate is not valid.
+	ErrorClassTLS ErrorClass = "tls"
+	// ErrorClassTimeout means the check timed out.
+	ErrorClassTimeout ErrorClass = "timeout"
+	// ErrorClassCanceled means the context of the check is canceled.
+	ErrorClassCanceled ErrorClass = "canceled"
+	// ErrorClassRedirect means there are too many redirects or a redirect loop.
+	ErrorClassRedirect ErrorClass = "redirect"
+	// ErrorClassStatus means the final status code is not acceptable.
+	ErrorClassStatus ErrorClass = "status"
+	// ErrorClassOther means the check failed for another reason.
+	ErrorClassOther ErrorClass = "other"
+)
+
+// StatusRange is an inclusive range of status codes, e.g. {200, 299}.
+type StatusRange struct {
+	Min int
+	Max int
+}
+
+// defaultAcceptStatus includes the status codes of a live URL if they are not given.
+var defaultAcceptStatus = []StatusRange{{200, 399}}
+
+// LiveOptions configures CheckLive.
+type LiveOptions struct {
+	// Client is the client that the requests are sent with. It is
+	// DefaultHTTPClient by default. The redirect policy of a *http.Client is
+	// not used, since the redirects are followed by CheckLive.
+	Client HTTPClient
+	// Transport is used by a new client if Client is not given.
+	Transport nethttp.RoundTripper
+	// Timeout is the timeout of each request. It is 5 seconds by default.
+	Timeout time.Duration
+	// UserAgent is sent with the requests. It is DefaultUserAgent by default.
+	UserAgent string
+	// NoHEAD makes CheckLive send a GET request only. Otherwise a HEAD request is
+	// sent first, and a GET request is sent if it is answered with a status
+	// code that is not accepted, since some servers do not answer HEAD
+	// requests properly. GET is not sent if HEAD fails to connect.
+	NoHEAD bool
+	// AcceptStatus includes the status codes of a live URL. It is 200-399 by default.
+	AcceptStatus []StatusRange
+	// MaxRedirects is the number of the redirects to follow. It is 10 by default.
+	MaxRedirects int
+	// Cache caches the results of the checks by their URLs and the options
+	// that change them, i.e. UserAgent, NoHEAD, AcceptStatus and MaxRedirects,
+	// if it is given.
+	Cache *Cache
+}
+
+// RedirectKind is the way that a response redirects.
+type RedirectKind string
+
+const (
+	// RedirectHTTP is a redirect by a 3xx status code and a Location header.
+	RedirectHTTP RedirectKind = "http"
+	// RedirectMetaRefresh is a redirect by a Refresh header or a
+	// <meta http-equiv="refresh"> tag.
+	RedirectMetaRefresh RedirectKind = "meta-refresh"
+	// RedirectCanonical is a canonical link that TraceRedirects follows
+	// when TraceOptions.FollowCanonical is set.
+	RedirectCanonical RedirectKind = "canonical"
+)
+
+// Hop is a response in a redirect chain.
+type Hop struct {
+	URL        string
+	StatusCode int
+	// Location is the URL that the response redirects to, if any.
+	Location string
+	// Kind is the way that the response redirects, if it does.
+	Kind RedirectKind
+	// Canonical is the canonical URL that the page declares by a
+	// <link rel="canonical"> tag or a Link header. It is only set by TraceRedirects.
+	Canonical string
+	Latency   time.Duration
+}
+
+// Permanent tells whether the hop is a permanent redirect, 301 or 308.
+func (h Hop) Permanent() bool {
+	return h.Kind == RedirectHTTP &&
+		(h.StatusCode == nethttp.StatusMovedPermanently || h.StatusCode == nethttp.StatusPermanentRedirect)
+}
+
+// TLSInfo is the information of the TLS connection of a response.
+type TLSInfo struct {
+	Version     string // e.g. "TLS 1.3"
+	CipherSuite string // e.g. "TLS_AES_128_GCM_SHA256"
+	ServerName  string
+	// Issuer and NotAfter belong to the certificate of the server.
+	Issuer   string
+	NotAfter time.Time
+}
+
+// LiveResult is the result of CheckLive.
+type LiveResult struct {
+	// Live tells whether the final status code is acceptable.
+	Live bool
+	// Method is the method of the last request, "HEAD" or "GET".
+	Method     string
+	StatusCode int
+	FinalURL   string
+	// Redirects includes the responses that are redirected, in their order.
+	Redirects []Hop
+	// Latency is the duration of the last request including its redirects.
+	Latency time.Duration
+	// TLS is nil if the final response is not over TLS.
+	TLS        *TLSInfo
+	ErrorClass ErrorClass
+	Err        error
+}
+
+// CheckLive checks whether the URL is up by sending a request to it and
+// following its redirects. The check can be canceled by the context.
+//
+// Example Usage:
+//
+// u, _ := NewURL("https://boratanrikulu.dev")
+// result := u.CheckLive(ctx, LiveOptions{UserAgent: "my-crawler/1.0"})
+// fmt.Println(result.Live, result.StatusCode, result.FinalURL) // true 200 "https://boratanrikulu.dev"
+func (u *URL) CheckLive(ctx context.Context, opts LiveOptions) LiveResult {
+	rawurl := u.requestURL()
+	if opts.Cache != nil {
+		if result, ok := opts.Cache.getHTTP(rawurl, opts); ok {
+			return result
+		}
+	}
+
+	client := newClient(opts.Client, opts.Transport)
+
+	methods := []string{nethttp.MethodHead, nethttp.MethodGet}
+	if opts.NoHEAD {
+		methods = methods[1:]
+	}
+
+	var result LiveResult
+	for _, method := range methods {
+		result = opts.check(ctx, client, method, rawurl)
+		// GET is only sent if HEAD is answered, but with a status code that
+		// is not accepted, e.g. 405 or 501, or with too many redirects.
+		// The failures of the connection, such as a DNS or a TLS error,
+		// would fail GET as well.
+		answered := result.ErrorClass == ErrorClassStatus || result.ErrorClass == ErrorClassRedirect
+		if !answered || ctx.Err() != nil {
+			break
+		}
+	}
+
+	if opts.Cache != nil {
+		opts.Cache.setHTTP(rawurl, opts, result)
+	}
+	return result
+}
+
+// newClient returns the client that the requests are sent with.
+// A *http.Client is copied, so it does not follow the redirects.
+func newClient(c HTTPClient, transport nethttp.RoundTripper) HTTPClient {
+	switch {
+	case c == nil && transport != nil:
+		c = &nethttp.Client{Transport: transport}
+	case c == nil:
+		c = DefaultHTTPClient
+	}
+
+	client, ok := c.(*nethttp.Client)
+	if !ok {
+		return c
+	}
+	copied := *client
+	copied.CheckRedirect = func(*nethttp.Request, []*nethttp.Request) error {
+		return nethttp.ErrUseLastResponse
+	}
+	return &copied
+}
+
+// check sends the requests with the method until the URL does not redirect.
+func (opts LiveOptions) check(ctx context.Context, client HTTPClient, method, rawurl string) LiveResult {
+	maxRedirects := opts.MaxRedirects
+	if maxRedirects == 0 {
+		maxRedirects = defaultMaxRedirects
+	}
+
+	result := LiveResult{Method: method, FinalURL: rawurl}
+	start := time.Now()
+	for {
+		hop, resp, _, err := fetch(ctx, client, method, rawurl, opts.UserAgent, opts.Timeout, 0)
+		if err != nil {
+			result.ErrorClass, result.Err = classifyError(err), err
+			break
+		}
+
+		result.StatusCode = hop.StatusCode
+		result.FinalURL = hop.URL
+		result.TLS = tlsInfo(resp.TLS)
+		if hop.Location == "" {
+			break
+		}
+		result.Redirects = append(result.Redirects, hop)
+		if len(result.Redirects) > maxRedirects {
+			result.ErrorClass, result.Err = ErrorClassRedirect, ErrTooManyRedirects
+			break
+		}
+		rawurl = hop.Location
+	}
+	result.Latency = time.Since(start)
+
+	if result.Err == nil {
+		result.Live = acceptStatus(result.StatusCode, opts.AcceptStatus)
+		if !result.Live {
+			result.ErrorClass, result.Err = ErrorClassStatus, ErrUnacceptableStatus
+		}
+	}
+	return result
+}
+
+// fetch sends a request and closes its response body after reading up to
+// bodyLimit bytes of it. The Location of the hop is set if the response is a
+// redirect, and it is resolved against the URL of the request.
+func fetch(ctx context.Context, client HTTPClient, method, rawurl, userAgent string, timeout time.Duration, bodyLimit int64) (Hop, *nethttp.Response, []byte, error) {
+	hop := Hop{URL: rawurl}
+
+	if timeout == 0 {
+		timeout = defaultLiveTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := nethttp.NewRequest(method, rawurl, nil)
+	if err != nil {
+		return hop, nil, nil, err
+	}
+	req = req.WithContext(ctx)
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return hop, nil, nil, err
+	}
+	var body []byte
+	if bodyLimit > 0 {
+		body, _ = ioutil.ReadAll(io.LimitReader(resp.Body, bodyLimit))
+	}
+	io.CopyN(ioutil.Discard, resp.Body, maxDrainedBody)
+	resp.Body.Close()
+	hop.Latency = time.Since(start)
+	hop.StatusCode = resp.StatusCode
+
+	if isRedirect(resp.StatusCode) {
+		if location := resp.Header.Get("Location"); location != "" {
+			hop.Location, err = resolveLocation(rawurl, location)
+			if err != nil {
+				return hop, nil, nil, err
+			}
+			hop.Kind = RedirectHTTP
+		}
+	}
+	return hop, resp, body, nil
+}
+
+// resolveLocation resolves the location against the URL.
+func resolveLocation(rawurl, location string) (string, error) {
+	base, err := neturl.Parse(rawurl)
+	if err != nil {
+		return "", err
+	}
+	ref, err := neturl.Parse(location)
+	if err != nil {
+		return "", err
+	}
+	return base.ResolveReference(ref).String(), nil
+}
+
+// isRedirect tells whether the status code is a redirect that has a Location.
+func isRedirect(code int) bool {
+	switch code {
+	case nethttp.StatusMovedPermanently, nethttp.StatusFound, nethttp.StatusSeeOther,
+		nethttp.StatusTemporaryRedirect, nethttp.StatusPermanentRedirect:
+		return true
+	}
+	return false
+}
+
+// acceptStatus tells whether the status code is in any of the ranges.
+func acceptStatus(code int, ranges []StatusRange) bool {
+	if len(ranges) == 0 {
+		ranges = defaultAcceptStatus
+	}
+	for _, r := range ranges {
+		if r.Min <= code && code <= r.Max {
+			return true
+		}
+	}
+	return false
+}
+
+// classifyError returns the class of the error of a request.
+func classifyError(err error) ErrorClass {
+	var (
+		dnsErr       *net.DNSError
+		opErr        *net.OpError
+		netErr       net.Error
+		authorityErr x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+		invalidErr   x509.CertificateInvalidError
+		recordErr    tls.RecordHeaderError
+	)
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ErrorClassCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorClassTimeout
+	case errors.Is(err, ErrTooManyRedirects):
+		return ErrorClassRedirect
+	case errors.As(err, &dnsErr) && dnsErr.IsTimeout:
+		return ErrorClassTimeout
+	case errors.As(err, &dnsErr):
+		return ErrorClassDNS
+	case errors.As(err, &authorityErr), errors.As(err, &hostnameErr),
+		errors.As(err, &invalidErr), errors.As(err, &recordErr):
+		return ErrorClassTLS
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorClassTimeout
+	case errors.As(err, &opErr), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return ErrorClassConnection
+	}
+	return ErrorClassOther
+}
+
+// isNotFound tells whether the error is a DNS error of a host that does not exist.
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// tlsVersions includes the names of the TLS versions.
+var tlsVersions = map[uint16]string{
+	tls.VersionTLS10: "TLS 1.0",
+	tls.VersionTLS11: "TLS 1.1",
+	tls.VersionTLS12: "TLS 1.2",
+	tls.VersionTLS13: "TLS 1.3",
+}
+
+// tlsInfo returns the information of the TLS connection, or nil if there is none.
+func tlsInfo(state *tls.ConnectionState) *TLSInfo {
+	if state == nil {
+		return nil
+	}
+
+	info := &TLSInfo{
+		Version:     tlsVersions[state.Version],
+		CipherSuite: tls.CipherSuiteName(state.CipherSuite),
+		ServerName:  state.ServerName,
+	}
+	if len(state.PeerCertificates) > 0 {
+		cert := state.PeerCertificates[0]
+		info.Issuer = cert.Issuer.String()
+		info.NotAfter = cert.NotAfter
+	}
+	return info
+}
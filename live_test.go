@@ -0,0 +1,307 @@
+package url
+
+import (
+	"context"
+	"errors"
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zeoagency/url/urltest"
+)
+
+// liveHandler returns the handler of the test server for CheckLive.
+func liveHandler() nethttp.Handler {
+	mux := nethttp.NewServeMux()
+	mux.HandleFunc("/ok", func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/no-head", func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if r.Method == nethttp.MethodHead {
+			w.WriteHeader(nethttp.StatusMethodNotAllowed)
+			return
+		}
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/moved", func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		nethttp.Redirect(w, r, "/found", nethttp.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/found", func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		nethttp.Redirect(w, r, "ok", nethttp.StatusFound)
+	})
+	mux.HandleFunc("/loop", func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		nethttp.Redirect(w, r, "/loop", nethttp.StatusTemporaryRedirect)
+	})
+	mux.HandleFunc("/missing", func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		nethttp.NotFound(w, r)
+	})
+	mux.HandleFunc("/slow", func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	})
+	mux.HandleFunc("/agent", func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if r.UserAgent() != "my-crawler/1.0" {
+			w.WriteHeader(nethttp.StatusForbidden)
+		}
+	})
+	return mux
+}
+
+func TestCheckLive(t *testing.T) {
+	server := httptest.NewServer(liveHandler())
+	defer server.Close()
+
+	var testValues = []struct {
+		Path             string
+		Options          LiveOptions
+		WantedLive       bool
+		WantedStatusCode int
+		WantedMethod     string
+		WantedFinalPath  string
+		WantedRedirects  int
+		WantedErrorClass ErrorClass
+	}{
+		{Path: "/ok", WantedLive: true, WantedStatusCode: 200, WantedMethod: "HEAD", WantedFinalPath: "/ok"},
+		{Path: "/ok", Options: LiveOptions{NoHEAD: true}, WantedLive: true, WantedStatusCode: 200, WantedMethod: "GET", WantedFinalPath: "/ok"},
+		{Path: "/no-head", WantedLive: true, WantedStatusCode: 200, WantedMethod: "GET", WantedFinalPath: "/no-head"},
+		{Path: "/moved", WantedLive: true, WantedStatusCode: 200, WantedMethod: "HEAD", WantedFinalPath: "/ok", WantedRedirects: 2},
+		{Path: "/missing", WantedStatusCode: 404, WantedMethod: "GET", WantedFinalPath: "/missing", WantedErrorClass: ErrorClassStatus},
+		{Path: "/missing", Options: LiveOptions{AcceptStatus: []StatusRange{{200, 299}, {404, 404}}}, WantedLive: true, WantedStatusCode: 404, WantedMethod: "HEAD", WantedFinalPath: "/missing"},
+		{Path: "/loop", Options: LiveOptions{MaxRedirects: 3}, WantedStatusCode: 307, WantedMethod: "GET", WantedFinalPath: "/loop", WantedRedirects: 4, WantedErrorClass: ErrorClassRedirect},
+		{Path: "/agent", WantedStatusCode: 403, WantedMethod: "GET", WantedFinalPath: "/agent", WantedErrorClass: ErrorClassStatus},
+		{Path: "/agent", Options: LiveOptions{UserAgent: "my-crawler/1.0"}, WantedLive: true, WantedStatusCode: 200, WantedMethod: "HEAD", WantedFinalPath: "/agent"},
+		{Path: "/slow", Options: LiveOptions{Timeout: 50 * time.Millisecond}, WantedMethod: "HEAD", WantedFinalPath: "/slow", WantedErrorClass: ErrorClassTimeout},
+	}
+
+	for _, testValue := range testValues {
+		u, err := NewURL(server.URL + testValue.Path)
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Path)
+		}
+
+		result := u.CheckLive(context.Background(), testValue.Options)
+		if result.Live != testValue.WantedLive {
+			t.Fatalf("[%s] Live is wrong: Wanted: \"%t\" - Got: \"%t\" (%v)", testValue.Path, testValue.WantedLive, result.Live, result.Err)
+		}
+		if result.StatusCode != testValue.WantedStatusCode {
+			t.Fatalf("[%s] StatusCode is wrong: Wanted: \"%d\" - Got: \"%d\"", testValue.Path, testValue.WantedStatusCode, result.StatusCode)
+		}
+		if result.Method != testValue.WantedMethod {
+			t.Fatalf("[%s] Method is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Path, testValue.WantedMethod, result.Method)
+		}
+		if result.FinalURL != server.URL+testValue.WantedFinalPath {
+			t.Fatalf("[%s] FinalURL is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Path, server.URL+testValue.WantedFinalPath, result.FinalURL)
+		}
+		if len(result.Redirects) != testValue.WantedRedirects {
+			t.Fatalf("[%s] Redirects are wrong: Wanted: \"%d\" - Got: \"%d\"", testValue.Path, testValue.WantedRedirects, len(result.Redirects))
+		}
+		if result.ErrorClass != testValue.WantedErrorClass {
+			t.Fatalf("[%s] ErrorClass is wrong: Wanted: \"%s\" - Got: \"%s\" (%v)", testValue.Path, testValue.WantedErrorClass, result.ErrorClass, result.Err)
+		}
+		if result.TLS != nil {
+			t.Fatalf("[%s] TLS must be nil for a plain HTTP server", testValue.Path)
+		}
+	}
+}
+
+func TestCheckLiveFallback(t *testing.T) {
+	client := urltest.NewHTTPClient()
+	client.Handle("https://boratanrikulu.dev/ok", urltest.Response{})
+	client.Handle("https://boratanrikulu.dev/missing", urltest.Response{StatusCode: 404})
+	client.Fail("https://missing.boratanrikulu.dev", urltest.NotFound("missing.boratanrikulu.dev"))
+
+	var testValues = []struct {
+		Input            string
+		WantedMethod     string
+		WantedRequests   int
+		WantedErrorClass ErrorClass
+	}{
+		{Input: "https://boratanrikulu.dev/ok", WantedMethod: "HEAD", WantedRequests: 1},
+		{Input: "https://boratanrikulu.dev/missing", WantedMethod: "GET", WantedRequests: 2, WantedErrorClass: ErrorClassStatus},
+		// The connection failures are not tried again with GET.
+		{Input: "https://missing.boratanrikulu.dev", WantedMethod: "HEAD", WantedRequests: 1, WantedErrorClass: ErrorClassDNS},
+		{Input: "https://refused.boratanrikulu.dev", WantedMethod: "HEAD", WantedRequests: 1, WantedErrorClass: ErrorClassConnection},
+	}
+
+	for _, testValue := range testValues {
+		u, err := NewURL(testValue.Input)
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Input)
+		}
+
+		requests := len(client.Requests())
+		result := u.CheckLive(context.Background(), LiveOptions{Client: client})
+		if result.Method != testValue.WantedMethod {
+			t.Fatalf("[%s] Method is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Input, testValue.WantedMethod, result.Method)
+		}
+		if got := len(client.Requests()) - requests; got != testValue.WantedRequests {
+			t.Fatalf("[%s] Requests are wrong: Wanted: \"%d\" - Got: \"%d\"", testValue.Input, testValue.WantedRequests, got)
+		}
+		if result.ErrorClass != testValue.WantedErrorClass {
+			t.Fatalf("[%s] ErrorClass is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Input, testValue.WantedErrorClass, result.ErrorClass)
+		}
+	}
+}
+
+func TestCheckLiveRequestURL(t *testing.T) {
+	var testValues = []struct {
+		Input  string
+		Modify func(u *URL)
+		Wanted string
+	}{
+		{Input: "https://boratanrikulu.dev/x?q=%zz&page=2", Wanted: "https://boratanrikulu.dev/x?q=%zz&page=2"},
+		{Input: "https://boratanrikulu.dev/?a=1;b=2", Wanted: "https://boratanrikulu.dev/?a=1;b=2"},
+		{Input: "https://Boratanrikulu.DEV/a%2fb", Wanted: "https://Boratanrikulu.DEV/a%2fb"},
+		{Input: "https://boratanrikulu.dev/x?q=%zz", Modify: func(u *URL) { u.Path = "/y" }, Wanted: "https://boratanrikulu.dev/y?q=%zz"},
+		{Input: "https://boratanrikulu.dev/x", Modify: func(u *URL) { u.Subdomains = []string{"www"} }, Wanted: "https://www.boratanrikulu.dev/x"},
+	}
+
+	for _, testValue := range testValues {
+		u, err := NewURL(testValue.Input)
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Input)
+		}
+		if testValue.Modify != nil {
+			testValue.Modify(u)
+		}
+
+		client := urltest.NewHTTPClient()
+		u.CheckLive(context.Background(), LiveOptions{Client: client, NoHEAD: true})
+		requests := client.Requests()
+		if len(requests) != 1 {
+			t.Fatalf("[%s] Requests are wrong: Wanted: \"1\" - Got: \"%d\"", testValue.Input, len(requests))
+		}
+		if got := requests[0].URL.String(); got != testValue.Wanted {
+			t.Fatalf("[%s] Requested URL is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Input, testValue.Wanted, got)
+		}
+	}
+}
+
+func TestCheckLiveRedirects(t *testing.T) {
+	server := httptest.NewServer(liveHandler())
+	defer server.Close()
+
+	u, err := NewURL(server.URL + "/moved")
+	if err != nil {
+		t.Fatalf("Error occur: %s", err)
+	}
+
+	result := u.CheckLive(context.Background(), LiveOptions{})
+	wanted := []Hop{
+		{URL: server.URL + "/moved", StatusCode: 301, Location: server.URL + "/found"},
+		{URL: server.URL + "/found", StatusCode: 302, Location: server.URL + "/ok"},
+	}
+	for i, hop := range result.Redirects {
+		if hop.URL != wanted[i].URL || hop.StatusCode != wanted[i].StatusCode || hop.Location != wanted[i].Location {
+			t.Fatalf("Redirect %d is wrong: Wanted: %+v - Got: %+v", i, wanted[i], hop)
+		}
+	}
+}
+
+func TestCheckLiveContext(t *testing.T) {
+	server := httptest.NewServer(liveHandler())
+	defer server.Close()
+
+	u, err := NewURL(server.URL + "/slow")
+	if err != nil {
+		t.Fatalf("Error occur: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	result := u.CheckLive(ctx, LiveOptions{})
+	if result.ErrorClass != ErrorClassTimeout || !errors.Is(result.Err, context.DeadlineExceeded) {
+		t.Fatalf("ErrorClass is wrong: Wanted: \"%s\" - Got: \"%s\" (%v)", ErrorClassTimeout, result.ErrorClass, result.Err)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	result = u.CheckLive(ctx, LiveOptions{})
+	if result.ErrorClass != ErrorClassCanceled {
+		t.Fatalf("ErrorClass is wrong: Wanted: \"%s\" - Got: \"%s\" (%v)", ErrorClassCanceled, result.ErrorClass, result.Err)
+	}
+}
+
+func TestCheckLiveTLS(t *testing.T) {
+	server := httptest.NewTLSServer(liveHandler())
+	defer server.Close()
+
+	u, err := NewURL(server.URL + "/ok")
+	if err != nil {
+		t.Fatalf("Error occur: %s", err)
+	}
+
+	result := u.CheckLive(context.Background(), LiveOptions{Client: server.Client()})
+	if !result.Live || result.TLS == nil {
+		t.Fatalf("TLS info is wrong: Got: %+v (%v)", result.TLS, result.Err)
+	}
+	if result.TLS.Version == "" || result.TLS.CipherSuite == "" || result.TLS.NotAfter.IsZero() {
+		t.Fatalf("TLS info is not complete: Got: %+v", result.TLS)
+	}
+
+	// The certificate of the test server is not trusted by the default client.
+	result = u.CheckLive(context.Background(), LiveOptions{})
+	if result.ErrorClass != ErrorClassTLS {
+		t.Fatalf("ErrorClass is wrong: Wanted: \"%s\" - Got: \"%s\" (%v)", ErrorClassTLS, result.ErrorClass, result.Err)
+	}
+}
+
+func TestCheckLiveConnection(t *testing.T) {
+	server := httptest.NewServer(liveHandler())
+	u, err := NewURL(server.URL + "/ok")
+	if err != nil {
+		t.Fatalf("Error occur: %s", err)
+	}
+	server.Close()
+
+	result := u.CheckLive(context.Background(), LiveOptions{})
+	if result.Live || result.ErrorClass != ErrorClassConnection {
+		t.Fatalf("ErrorClass is wrong: Wanted: \"%s\" - Got: \"%s\" (%v)", ErrorClassConnection, result.ErrorClass, result.Err)
+	}
+}
+
+// closeCountingTransport counts the response bodies that are closed.
+type closeCountingTransport struct {
+	opened, closed int32
+}
+
+func (t *closeCountingTransport) RoundTrip(req *nethttp.Request) (*nethttp.Response, error) {
+	resp, err := nethttp.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	atomic.AddInt32(&t.opened, 1)
+	resp.Body = &closeCountingBody{ReadCloser: resp.Body, closed: &t.closed}
+	return resp, nil
+}
+
+type closeCountingBody struct {
+	io.ReadCloser
+	closed *int32
+}
+
+func (b *closeCountingBody) Close() error {
+	atomic.AddInt32(b.closed, 1)
+	return b.ReadCloser.Close()
+}
+
+func TestCheckLiveClosesBodies(t *testing.T) {
+	server := httptest.NewServer(liveHandler())
+	defer server.Close()
+
+	u, err := NewURL(server.URL + "/moved")
+	if err != nil {
+		t.Fatalf("Error occur: %s", err)
+	}
+
+	transport := &closeCountingTransport{}
+	u.CheckLive(context.Background(), LiveOptions{Transport: transport, NoHEAD: true})
+	if transport.opened != 3 || transport.closed != transport.opened {
+		t.Fatalf("Bodies are not closed: Opened: %d - Closed: %d", transport.opened, transport.closed)
+	}
+}
@@ -0,0 +1,349 @@
+package url
+
+import (
+	"sort"
+	"strings"
+
+	neturl "net/url"
+)
+
+// NormalizeFlags selects the rules that a URL is normalized by.
+// The flags can be combined with "|", e.g. NormalizeSafe | NormalizeRemoveFragment.
+type NormalizeFlags uint
+
+const (
+	// NormalizeLowercaseScheme lowercases the scheme, "HTTP://" to "http://".
+	NormalizeLowercaseScheme NormalizeFlags = 1 << iota
+	// NormalizeLowercaseHost lowercases the host and turns it into its ASCII form,
+	// "Example.COM" to "example.com" and "münchen.de" to "xn--mnchen-3ya.de".
+	NormalizeLowercaseHost
+	// NormalizeRemoveDefaultPort removes the port if it is the default port of
+	// the scheme, "http://example.com:80/" to "http://example.com/".
+	NormalizeRemoveDefaultPort
+	// NormalizeRemoveDotSegments removes the "." and ".." segments of the path,
+	// "/a/./b/../c" to "/a/c".
+	NormalizeRemoveDotSegments
+	// NormalizeUppercaseEscapes uppercases the hex digits of the percent-escapes,
+	// "%7b" to "%7B".
+	NormalizeUppercaseEscapes
+	// NormalizeDecodeUnreserved decodes the percent-escapes of the unreserved
+	// characters, "%7Efoo" to "~foo".
+	NormalizeDecodeUnreserved
+	// NormalizeSortQuery sorts the query parameters by their keys,
+	// "?b=2&a=1" to "?a=1&b=2". The order of the values of a key is kept.
+	NormalizeSortQuery
+	// NormalizeRemoveFragment removes the fragment, "/page#top" to "/page".
+	NormalizeRemoveFragment
+	// NormalizeRemoveEmptyQuery removes the "?" of an empty query, "/page?" to "/page".
+	NormalizeRemoveEmptyQuery
+	// NormalizeAddTrailingSlash adds a trailing slash to the path unless its last
+	// segment looks like a file, "/blog" to "/blog/" but not "/blog/post.html".
+	// An empty path becomes "/".
+	NormalizeAddTrailingSlash
+	// NormalizeRemoveTrailingSlash removes the trailing slash of the path,
+	// "/blog/" to "/blog" and "/" to "".
+	NormalizeRemoveTrailingSlash
+	// NormalizeRemoveWWW removes the "www." prefix of the host if it is above
+	// the registrable domain, "www.example.com" to "example.com" but not "www.co.uk".
+	NormalizeRemoveWWW
+)
+
+const (
+	// NormalizeSafe includes the rules that do not change the meaning of a URL.
+	// source: https://tools.ietf.org/html/rfc3986#section-6.2.2
+	NormalizeSafe = NormalizeLowercaseScheme | NormalizeLowercaseHost | NormalizeRemoveDefaultPort |
+		NormalizeRemoveDotSegments | NormalizeUppercaseEscapes | NormalizeDecodeUnreserved
+
+	// NormalizeDeduplicate includes the rules that make the URLs that most likely
+	// point to the same page equal.
+	NormalizeDeduplicate = NormalizeSafe | NormalizeSortQuery | NormalizeRemoveFragment | NormalizeRemoveEmptyQuery
+)
+
+// Normalize returns the normalized form of the URL by the given rules.
+// The URL is assembled from the current values of its fields like String
+// does, and "www" is removed only if it is a subdomain, so "www.co.uk" is kept.
+//
+// Example Usage:
+//
+// u, _ := NewURL("HTTP://www.Example.COM:80/a/./b/../%7ec?z=1&a=2#top")
+// fmt.Println(u.Normalize(NormalizeDeduplicate)) // "http://www.example.com/a/~c?a=2&z=1"
+func (u *URL) Normalize(flags NormalizeFlags) string {
+	if flags&NormalizeRemoveWWW != 0 {
+		flags &^= NormalizeRemoveWWW
+		if len(u.Subdomains) > 0 && strings.EqualFold(u.Subdomains[0], "www") {
+			stripped := *u
+			stripped.Subdomains = u.Subdomains[1:]
+			u = &stripped
+		}
+	}
+
+	rawurl := u.String()
+	normalized, err := normalizeURL(rawurl, flags, nil)
+	if err != nil {
+		return rawurl
+	}
+	return normalized
+}
+
+// NormalizeURL returns the normalized form of the given URL by the given rules.
+// It is a shorthand for DefaultParser().NormalizeURL(rawurl, flags).
+func NormalizeURL(rawurl string, flags NormalizeFlags) (string, error) {
+	return defaultParser.NormalizeURL(rawurl, flags)
+}
+
+// NormalizeURL returns the normalized form of the given URL by the given rules.
+// The list of the Parser tells whether "www" is a subdomain for
+// NormalizeRemoveWWW, so "www.example.co.uk" becomes "example.co.uk", but
+// "www.co.uk" is kept.
+//
+// Unlike Parse, the host of the URL does not need to have a known suffix,
+// so any URL that net/url can parse can be normalized.
+func (p *Parser) NormalizeURL(rawurl string, flags NormalizeFlags) (string, error) {
+	return normalizeURL(rawurl, flags, p)
+}
+
+// normalizeURL returns the normalized form of the given URL by the given rules.
+// The Parser is only used for NormalizeRemoveWWW.
+func normalizeURL(rawurl string, flags NormalizeFlags, p *Parser) (string, error) {
+	u, err := neturl.Parse(rawurl)
+	if err != nil {
+		return "", newParseError(rawurl, ComponentURL, ReasonMalformed)
+	}
+
+	var b strings.Builder
+
+	// Scheme
+	if u.Scheme != "" {
+		if flags&NormalizeLowercaseScheme != 0 {
+			b.WriteString(u.Scheme)
+		} else {
+			b.WriteString(rawurl[:len(u.Scheme)])
+		}
+		b.WriteByte(':')
+	}
+
+	// Authority
+	if u.Host != "" || u.User != nil {
+		b.WriteString("//")
+		if u.User != nil {
+			b.WriteString(u.User.String())
+			b.WriteByte('@')
+		}
+		b.WriteString(normalizeHost(u.Hostname(), flags, p))
+
+		port := u.Port()
+		if flags&NormalizeRemoveDefaultPort != 0 && port == DefaultPort(u.Scheme) {
+			port = ""
+		}
+		if port != "" {
+			b.WriteByte(':')
+			b.WriteString(port)
+		}
+	}
+
+	// Path
+	path := u.EscapedPath()
+	if u.Opaque != "" {
+		path = u.Opaque
+	}
+	path = normalizeEscapes(path, flags)
+	if flags&NormalizeRemoveDotSegments != 0 && u.Opaque == "" {
+		path = removeDotSegments(path)
+	}
+	if u.Opaque == "" {
+		path = normalizeTrailingSlash(path, u.Host != "", flags)
+	}
+	b.WriteString(path)
+
+	// Query
+	query := normalizeEscapes(u.RawQuery, flags)
+	if flags&NormalizeSortQuery != 0 {
+		query = sortQuery(query)
+	}
+	if query != "" || (u.ForceQuery && flags&NormalizeRemoveEmptyQuery == 0) {
+		b.WriteByte('?')
+		b.WriteString(query)
+	}
+
+	// Fragment
+	if u.Fragment != "" && flags&NormalizeRemoveFragment == 0 {
+		b.WriteByte('#')
+		b.WriteString(normalizeEscapes(u.EscapedFragment(), flags))
+	}
+
+	return b.String(), nil
+}
+
+// normalizeHost returns the host in its normalized form with the brackets
+// of the IPv6 addresses.
+func normalizeHost(host string, flags NormalizeFlags, p *Parser) string {
+	hostType, ip := parseIPHost(host)
+	if hostType == HostIPv6 {
+		if flags&NormalizeLowercaseHost != 0 {
+			host = ip
+		}
+		return "[" + strings.Replace(host, "%", "%25", 1) + "]"
+	}
+
+	if flags&NormalizeLowercaseHost != 0 {
+		if ascii, err := toASCII(host); err == nil {
+			host = ascii
+		}
+	}
+	if flags&NormalizeRemoveWWW != 0 && hostType == HostDomain && p != nil && p.isWWWSubdomain(host) {
+		host = host[len("www."):]
+	}
+	return host
+}
+
+// isWWWSubdomain tells whether the host starts with a "www" label that is
+// above its registrable domain by the list of the Parser. A suffix that is
+// not on the list is taken as a single label.
+func (p *Parser) isWWWSubdomain(host string) bool {
+	if len(host) < len("www.") || !strings.EqualFold(host[:len("www.")], "www.") {
+		return false
+	}
+
+	labels := strings.Split(strings.ToLower(strings.TrimSuffix(host, ".")), ".")[1:]
+	count, _ := p.List().split(labels, p.icannOnly)
+	if count == 0 {
+		count = 1
+	}
+	return len(labels) > count
+}
+
+// normalizeEscapes uppercases the percent-escapes and decodes the ones of the
+// unreserved characters by the given rules.
+func normalizeEscapes(s string, flags NormalizeFlags) string {
+	if flags&(NormalizeUppercaseEscapes|NormalizeDecodeUnreserved) == 0 || !strings.Contains(s, "%") {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' || i+2 >= len(s) || !isHex(s[i+1]) || !isHex(s[i+2]) {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		c := unhex(s[i+1])<<4 | unhex(s[i+2])
+		switch {
+		case flags&NormalizeDecodeUnreserved != 0 && isUnreserved(c):
+			b.WriteByte(c)
+		case flags&NormalizeUppercaseEscapes != 0:
+			b.WriteByte('%')
+			b.WriteString(strings.ToUpper(s[i+1 : i+3]))
+		default:
+			b.WriteString(s[i : i+3])
+		}
+		i += 2
+	}
+	return b.String()
+}
+
+// removeDotSegments removes the "." and ".." segments of the path.
+// source: https://tools.ietf.org/html/rfc3986#section-5.2.4
+func removeDotSegments(path string) string {
+	var output []string
+	input := path
+	for input != "" {
+		switch {
+		case strings.HasPrefix(input, "../"):
+			input = input[3:]
+		case strings.HasPrefix(input, "./"):
+			input = input[2:]
+		case strings.HasPrefix(input, "/./"):
+			input = input[2:]
+		case input == "/.":
+			input = "/"
+		case strings.HasPrefix(input, "/../"):
+			input = input[3:]
+			if len(output) > 0 {
+				output = output[:len(output)-1]
+			}
+		case input == "/..":
+			input = "/"
+			if len(output) > 0 {
+				output = output[:len(output)-1]
+			}
+		case input == "." || input == "..":
+			input = ""
+		default:
+			// Move the first segment with its leading "/" to the output.
+			end := strings.Index(input[1:], "/")
+			if end < 0 {
+				end = len(input)
+			} else {
+				end++
+			}
+			output = append(output, input[:end])
+			input = input[end:]
+		}
+	}
+	return strings.Join(output, "")
+}
+
+// normalizeTrailingSlash adds or removes the trailing slash of the path by
+// the given rules.
+func normalizeTrailingSlash(path string, hasHost bool, flags NormalizeFlags) string {
+	switch {
+	case flags&NormalizeRemoveTrailingSlash != 0:
+		return strings.TrimSuffix(path, "/")
+	case flags&NormalizeAddTrailingSlash != 0:
+		if path == "" {
+			if hasHost {
+				return "/"
+			}
+			return path
+		}
+		last := path[strings.LastIndex(path, "/")+1:]
+		if last != "" && !strings.Contains(last, ".") {
+			return path + "/"
+		}
+	}
+	return path
+}
+
+// sortQuery sorts the parameters of the raw query by their keys.
+func sortQuery(query string) string {
+	if query == "" {
+		return query
+	}
+
+	params := strings.Split(query, "&")
+	sort.SliceStable(params, func(i, j int) bool {
+		return queryKey(params[i]) < queryKey(params[j])
+	})
+	return strings.Join(params, "&")
+}
+
+// queryKey returns the key of a raw query parameter.
+func queryKey(param string) string {
+	if i := strings.Index(param, "="); i >= 0 {
+		return param[:i]
+	}
+	return param
+}
+
+// isUnreserved tells whether c is an unreserved character.
+// source: https://tools.ietf.org/html/rfc3986#section-2.3
+func isUnreserved(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+// isHex tells whether c is a hex digit.
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// unhex returns the value of the hex digit.
+func unhex(c byte) byte {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0'
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10
+	}
+	return c - 'A' + 10
+}
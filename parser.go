@@ -0,0 +1,165 @@
+package url
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// Parser parses URLs by resolving their public suffixes on a List.
+//
+// The list of a Parser can be swapped by SetList while it is in use,
+// so a long-running service can load a fresh list without a restart.
+//
+// Example Usage:
+//
+// f, _ := os.Open("public_suffix_list.dat")
+// l, _ := ParseList(f)
+// p := NewParser(WithList(l))
+// u, _ := p.Parse("https://foo.github.io")
+// fmt.Println(u.Domain) // "foo"
+type Parser struct {
+	list          atomic.Value // *List
+	icannOnly     bool
+	hostPolicy    HostPolicy
+	defaultScheme string
+
+	// rejectSpecialUse and privateSuffixes configure the special-use domain names.
+	rejectSpecialUse bool
+	privateSuffixes  map[string]bool
+
+	// geoTargets is the table of GeoTarget. It is nil if the default one is
+	// used, and it is not modified after the Parser is created.
+	geoTargets map[string]string
+}
+
+// Option is a function that configures a Parser.
+type Option func(*Parser)
+
+// WithList sets the list that the Parser works with.
+// The list that is generated into the package is used if it is not given.
+func WithList(l *List) Option {
+	return func(p *Parser) {
+		p.SetList(l)
+	}
+}
+
+// WithICANNOnly makes the Parser ignore the rules from the PRIVATE section
+// of the list, so "foo.github.io" is split as "foo.github" and "io".
+func WithICANNOnly() Option {
+	return func(p *Parser) {
+		p.icannOnly = true
+	}
+}
+
+// WithHostPolicy makes the Parser accept the hosts that the policy allows,
+// e.g. WithHostPolicy(AllowUnderscore | AllowTrailingDot).
+// The hosts are validated strictly by the LDH rule if it is not given.
+func WithHostPolicy(policy HostPolicy) Option {
+	return func(p *Parser) {
+		p.hostPolicy = policy
+	}
+}
+
+// WithoutSpecialUse makes the Parser reject the special-use domain names,
+// e.g. "localhost", "printer.local" and "foo.onion". They are accepted and
+// their kinds are kept in SpecialUse if it is not given.
+// The private suffixes of WithPrivateSuffixes are accepted anyway.
+func WithoutSpecialUse() Option {
+	return func(p *Parser) {
+		p.rejectSpecialUse = true
+	}
+}
+
+// WithPrivateSuffixes registers the suffixes of a private network, e.g.
+// "corp.example", so the hosts under them are accepted and split by them.
+// The hosts are marked with SpecialUsePrivate.
+//
+// Example Usage:
+//
+// p := NewParser(WithPrivateSuffixes("corp.example"))
+// u, _ := p.Parse("https://wiki.intranet.corp.example")
+// fmt.Println(u.Domain, u.Suffix) // "intranet" "corp.example"
+func WithPrivateSuffixes(suffixes ...string) Option {
+	return func(p *Parser) {
+		if p.privateSuffixes == nil {
+			p.privateSuffixes = make(map[string]bool)
+		}
+		for _, suffix := range suffixes {
+			// Hosts are matched in their ASCII forms, so are the suffixes.
+			if suffix, err := toASCII(strings.Trim(suffix, ".")); err == nil && suffix != "" {
+				p.privateSuffixes[suffix] = true
+			}
+		}
+	}
+}
+
+// WithGeoTargets overrides the entries of the table that GeoTarget looks the
+// public suffixes up in, e.g. to target "io" to "IO", or "com.tr" to GeoGeneric.
+// The table is copied and merged over the curated one, so the table can be
+// modified by the caller afterwards without changing the Parser.
+//
+// Example Usage:
+//
+// p := NewParser(WithGeoTargets(map[string]string{"io": "IO"}))
+// u, _ := p.Parse("https://boratanrikulu.io")
+// fmt.Println(u.GeoTarget()) // "IO"
+func WithGeoTargets(table map[string]string) Option {
+	return func(p *Parser) {
+		targets := make(map[string]string, len(geoTargets)+len(table))
+		for suffix, target := range geoTargets {
+			targets[suffix] = target
+		}
+		for suffix, target := range p.geoTargets {
+			targets[suffix] = target
+		}
+		for suffix, target := range table {
+			// Suffixes are matched in their ASCII forms.
+			if suffix, err := toASCII(strings.Trim(suffix, ".")); err == nil && suffix != "" {
+				targets[suffix] = target
+			}
+		}
+		p.geoTargets = targets
+	}
+}
+
+// WithDefaultScheme sets the scheme that ParseLenient adds to the URLs that
+// do not have a scheme. It is "https" if it is not given.
+func WithDefaultScheme(scheme string) Option {
+	return func(p *Parser) {
+		p.defaultScheme = scheme
+	}
+}
+
+// NewParser returns a new Parser with the given options.
+func NewParser(opts ...Option) *Parser {
+	p := &Parser{defaultScheme: "https"}
+	p.list.Store(defaultList)
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// defaultParser is the Parser that NewURL uses.
+var defaultParser = NewParser()
+
+// DefaultParser returns the Parser that NewURL uses.
+// Its list can be swapped by SetList to update NewURL's list.
+func DefaultParser() *Parser {
+	return defaultParser
+}
+
+// List returns the list that the Parser works with.
+func (p *Parser) List() *List {
+	return p.list.Load().(*List)
+}
+
+// SetList swaps the list of the Parser atomically.
+// Parse calls that are already running keep working with the old list.
+// A nil list sets the list that is generated into the package back.
+func (p *Parser) SetList(l *List) {
+	if l == nil {
+		l = defaultList
+	}
+	p.list.Store(l)
+}
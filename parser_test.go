@@ -0,0 +1,196 @@
+package url
+
+import (
+	"strings"
+	"testing"
+)
+
+var testPSL = `// ===BEGIN ICANN DOMAINS===
+// VERSION: 2021-01-28_07-07-02_UTC
+// COMMIT: 0123abcd
+com
+uk
+co.uk
+*.ck
+!www.ck
+// ===END ICANN DOMAINS===
+// ===BEGIN PRIVATE DOMAINS===
+blogspot.co.uk
+example.com
+// ===END PRIVATE DOMAINS===
+`
+
+var testIANA = `# Version 2021012800, Last Updated Thu Jan 28 07:07:02 2021 UTC
+COM
+DEV
+TR
+`
+
+func TestParseList(t *testing.T) {
+	var testValues = []struct {
+		List          string
+		Input         string
+		WantedDomain  string
+		WantedSuffix  string
+		WantedICANN   bool
+		WantedVersion string
+		WantedUpdated string
+		ShouldFail    bool
+	}{
+		{
+			List:          testPSL,
+			Input:         "https://www.bar.blogspot.co.uk",
+			WantedDomain:  "bar",
+			WantedSuffix:  "blogspot.co.uk",
+			WantedICANN:   false,
+			WantedVersion: "psl:2021-01-28_07-07-02_UTC 0123abcd",
+		},
+		{
+			List:          testPSL,
+			Input:         "https://foo.example.com",
+			WantedDomain:  "foo",
+			WantedSuffix:  "example.com",
+			WantedICANN:   false,
+			WantedVersion: "psl:2021-01-28_07-07-02_UTC 0123abcd",
+		},
+		{
+			List:          testPSL,
+			Input:         "https://a.www.ck",
+			WantedDomain:  "www",
+			WantedSuffix:  "ck",
+			WantedICANN:   true,
+			WantedVersion: "psl:2021-01-28_07-07-02_UTC 0123abcd",
+		},
+		{
+			List:       testPSL,
+			Input:      "https://boratanrikulu.dev",
+			ShouldFail: true,
+		},
+		{
+			List:          testIANA,
+			Input:         "https://boratanrikulu.dev.tr",
+			WantedDomain:  "dev",
+			WantedSuffix:  "tr",
+			WantedICANN:   true,
+			WantedVersion: "iana:2021012800",
+			WantedUpdated: "Thu Jan 28 07:07:02 2021 UTC",
+		},
+		{
+			List:       testIANA,
+			Input:      "https://boratanrikulu.co.uk",
+			ShouldFail: true,
+		},
+	}
+
+	for _, testValue := range testValues {
+		l, err := ParseList(strings.NewReader(testValue.List))
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Input)
+		}
+
+		if testValue.WantedVersion != "" && testValue.WantedVersion != l.Version() {
+			t.Fatalf("[%s] Version is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Input, testValue.WantedVersion, l.Version())
+		}
+
+		if testValue.WantedVersion != "" && testValue.WantedUpdated != l.IANAUpdated() {
+			t.Fatalf("[%s] IANA updated date is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Input, testValue.WantedUpdated, l.IANAUpdated())
+		}
+
+		u, err := NewParser(WithList(l)).Parse(testValue.Input)
+		if testValue.ShouldFail {
+			if err == nil {
+				t.Fatalf("[%s] Error must be occurred, but did not", testValue.Input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Input)
+		}
+
+		if testValue.WantedDomain != u.Domain {
+			t.Fatalf("[%s] Domain is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Input, testValue.WantedDomain, u.Domain)
+		}
+
+		if testValue.WantedSuffix != u.Suffix {
+			t.Fatalf("[%s] Suffix is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Input, testValue.WantedSuffix, u.Suffix)
+		}
+
+		if testValue.WantedICANN != u.ICANN {
+			t.Fatalf("[%s] ICANN is wrong: Wanted: \"%t\" - Got: \"%t\"", testValue.Input, testValue.WantedICANN, u.ICANN)
+		}
+	}
+}
+
+func TestParseListWithoutRules(t *testing.T) {
+	_, err := ParseList(strings.NewReader("// nothing here\n"))
+	if err == nil {
+		t.Fatalf("Error must be occurred, but did not")
+	}
+}
+
+func TestParserICANNOnly(t *testing.T) {
+	u, err := NewParser(WithICANNOnly()).Parse("https://foo.github.io")
+	if err != nil {
+		t.Fatalf("Error occur: %s", err)
+	}
+
+	if u.Domain != "github" || u.Suffix != "io" || len(u.Subdomains) != 1 {
+		t.Fatalf("ICANN only split is wrong: Got: %q %q %q", u.Subdomains, u.Domain, u.Suffix)
+	}
+}
+
+func TestParserHostPolicy(t *testing.T) {
+	var testValues = []struct {
+		Input        string
+		Policy       HostPolicy
+		WantedDomain string
+		ShouldFail   bool
+	}{
+		{Input: "https://_dmarc.boratanrikulu.dev", Policy: AllowUnderscore, WantedDomain: "_dmarc.boratanrikulu.dev"},
+		{Input: "https://_dmarc.boratanrikulu.dev", Policy: AllowTrailingDot, ShouldFail: true},
+		{Input: "https://boratanrikulu.dev.", Policy: AllowTrailingDot, WantedDomain: "boratanrikulu.dev"},
+		{Input: "https://boratanrikulu.dev..", Policy: AllowTrailingDot, ShouldFail: true},
+		{Input: "https://www..boratanrikulu.dev", Policy: AllowEmptyLabels, WantedDomain: "www.boratanrikulu.dev"},
+		{Input: "https://.com", Policy: AllowEmptyLabels, ShouldFail: true},
+		{Input: "https://-bad-.com", Policy: AllowUnderscore | AllowTrailingDot | AllowEmptyLabels, ShouldFail: true},
+		{Input: "https://_sip._tcp.seo.do.", Policy: AllowUnderscore | AllowTrailingDot, WantedDomain: "_sip._tcp.seo.do"},
+	}
+
+	for _, testValue := range testValues {
+		u, err := NewParser(WithHostPolicy(testValue.Policy)).Parse(testValue.Input)
+		if testValue.ShouldFail {
+			if err == nil {
+				t.Fatalf("[%s] Error must be occurred, but did not", testValue.Input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Input)
+		}
+
+		if u.FullDomain != testValue.WantedDomain {
+			t.Fatalf("[%s] FullDomain is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Input, testValue.WantedDomain, u.FullDomain)
+		}
+	}
+}
+
+func TestParserSetList(t *testing.T) {
+	p := NewParser()
+	if _, err := p.Parse("https://boratanrikulu.dev"); err != nil {
+		t.Fatalf("Error occur: %s", err)
+	}
+
+	l, err := ParseList(strings.NewReader(testPSL))
+	if err != nil {
+		t.Fatalf("Error occur: %s", err)
+	}
+	p.SetList(l)
+	if _, err := p.Parse("https://boratanrikulu.dev"); err == nil {
+		t.Fatalf("Error must be occurred after the list is swapped, but did not")
+	}
+
+	p.SetList(nil)
+	if p.List() != defaultList {
+		t.Fatalf("List must be the default one after a nil list is set")
+	}
+}
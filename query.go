@@ -0,0 +1,146 @@
+package url
+
+import (
+	"strings"
+
+	neturl "net/url"
+)
+
+// QueryParam is a parameter of a query with its decoded and raw forms.
+type QueryParam struct {
+	// Key and Value are the decoded forms, e.g. "a lovely query".
+	Key   string
+	Value string
+	// RawKey and RawValue are the forms as they are in the URL, e.g. "a+lovely+query".
+	RawKey   string
+	RawValue string
+	// HasValue tells whether the parameter has a "=", so "a" and "a=" can be told apart.
+	HasValue bool
+}
+
+// Query is a query as an ordered list of its parameters.
+// Unlike the Queries map, it keeps the order of the parameters and their
+// original encoding.
+type Query []QueryParam
+
+// QueryEncoding selects how Query.Encode encodes the parameters.
+type QueryEncoding int
+
+const (
+	// QueryEncodingRaw keeps the raw forms of the parameters that are not
+	// modified, so an unmodified query is reproduced byte-for-byte.
+	// The modified ones are escaped like QueryEncodingPlus does.
+	QueryEncodingRaw QueryEncoding = iota
+	// QueryEncodingPlus escapes all the parameters, spaces as "+".
+	QueryEncodingPlus
+	// QueryEncodingPercent escapes all the parameters, spaces as "%20".
+	QueryEncodingPercent
+)
+
+// parseQuery returns the parameters of the raw query in their order.
+// The parameters that can not be decoded are kept in their raw forms.
+func parseQuery(rawQuery string) Query {
+	if rawQuery == "" {
+		return Query{}
+	}
+
+	params := strings.Split(rawQuery, "&")
+	query := make(Query, 0, len(params))
+	for _, param := range params {
+		p := QueryParam{RawKey: param}
+		if i := strings.Index(param, "="); i >= 0 {
+			p.RawKey, p.RawValue, p.HasValue = param[:i], param[i+1:], true
+		}
+		p.Key = unescapeQuery(p.RawKey)
+		p.Value = unescapeQuery(p.RawValue)
+		query = append(query, p)
+	}
+	return query
+}
+
+// Get returns the first value of the key, or an empty string if there is none.
+func (q Query) Get(key string) string {
+	for _, p := range q {
+		if p.Key == key {
+			return p.Value
+		}
+	}
+	return ""
+}
+
+// Values returns the query as a map like the Queries field of a URL.
+// Empty parameters, such as the ones between "&&", are left out.
+func (q Query) Values() map[string][]string {
+	values := make(map[string][]string)
+	for _, p := range q {
+		if p.RawKey == "" && !p.HasValue {
+			continue
+		}
+		values[p.Key] = append(values[p.Key], p.Value)
+	}
+	return values
+}
+
+// Encode returns the query in its raw form with the given encoding.
+func (q Query) Encode(encoding QueryEncoding) string {
+	var b strings.Builder
+	for i, p := range q {
+		if i > 0 {
+			b.WriteByte('&')
+		}
+
+		if encoding == QueryEncodingRaw && unescapeQuery(p.RawKey) == p.Key && unescapeQuery(p.RawValue) == p.Value {
+			b.WriteString(p.RawKey)
+			if p.HasValue || p.RawValue != "" {
+				b.WriteByte('=')
+				b.WriteString(p.RawValue)
+			}
+			continue
+		}
+
+		b.WriteString(escapeQuery(p.Key, encoding))
+		if p.HasValue || p.Value != "" {
+			b.WriteByte('=')
+			b.WriteString(escapeQuery(p.Value, encoding))
+		}
+	}
+	return b.String()
+}
+
+// unescapeQuery returns the decoded form of the raw query component,
+// or the raw form if it can not be decoded.
+func unescapeQuery(raw string) string {
+	s, err := neturl.QueryUnescape(raw)
+	if err != nil {
+		return raw
+	}
+	return s
+}
+
+// escapeQuery returns the escaped form of the query component.
+func escapeQuery(s string, encoding QueryEncoding) string {
+	escaped := neturl.QueryEscape(s)
+	if encoding == QueryEncodingPercent {
+		escaped = strings.Replace(escaped, "+", "%20", -1)
+	}
+	return escaped
+}
+
+// equalValues tells whether the two query maps have the same values.
+func equalValues(a, b map[string][]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, values := range a {
+		other, ok := b[key]
+		if !ok || len(values) != len(other) {
+			return false
+		}
+		for i := range values {
+			if values[i] != other[i] {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+package url
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestQueryParams(t *testing.T) {
+	u, err := NewURL("https://boratanrikulu.dev/search?z=another+query&q=a%20lovely+query&z=again&flag&empty=&&x=%7e")
+	if err != nil {
+		t.Fatalf("Error occur: %s", err)
+	}
+
+	wanted := Query{
+		{Key: "z", Value: "another query", RawKey: "z", RawValue: "another+query", HasValue: true},
+		{Key: "q", Value: "a lovely query", RawKey: "q", RawValue: "a%20lovely+query", HasValue: true},
+		{Key: "z", Value: "again", RawKey: "z", RawValue: "again", HasValue: true},
+		{Key: "flag", Value: "", RawKey: "flag", RawValue: "", HasValue: false},
+		{Key: "empty", Value: "", RawKey: "empty", RawValue: "", HasValue: true},
+		{Key: "", Value: "", RawKey: "", RawValue: "", HasValue: false},
+		{Key: "x", Value: "~", RawKey: "x", RawValue: "%7e", HasValue: true},
+	}
+	if fmt.Sprint(wanted) != fmt.Sprint(u.QueryParams) {
+		t.Fatalf("QueryParams are wrong: Wanted: %v - Got: %v", wanted, u.QueryParams)
+	}
+
+	if got := u.QueryParams.Get("z"); got != "another query" {
+		t.Fatalf("Get is wrong: Wanted: \"another query\" - Got: \"%s\"", got)
+	}
+
+	if fmt.Sprint(u.Queries) != fmt.Sprint(u.QueryParams.Values()) {
+		t.Fatalf("Values are wrong: Wanted: %v - Got: %v", u.Queries, u.QueryParams.Values())
+	}
+}
+
+func TestQueryEncode(t *testing.T) {
+	var testValues = []struct {
+		RawQuery string
+		Encoding QueryEncoding
+		Wanted   string
+	}{
+		{
+			RawQuery: "z=another+query&q=a%20lovely+query&z=again&flag&empty=&&x=%7e",
+			Encoding: QueryEncodingRaw,
+			Wanted:   "z=another+query&q=a%20lovely+query&z=again&flag&empty=&&x=%7e",
+		},
+		{
+			RawQuery: "z=another+query&q=a%20lovely+query&x=%7e",
+			Encoding: QueryEncodingPlus,
+			Wanted:   "z=another+query&q=a+lovely+query&x=~",
+		},
+		{
+			RawQuery: "z=another+query&q=a%20lovely+query&x=%7e",
+			Encoding: QueryEncodingPercent,
+			Wanted:   "z=another%20query&q=a%20lovely%20query&x=~",
+		},
+	}
+
+	for _, testValue := range testValues {
+		if got := parseQuery(testValue.RawQuery).Encode(testValue.Encoding); got != testValue.Wanted {
+			t.Fatalf("[%s] Encoded query is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.RawQuery, testValue.Wanted, got)
+		}
+	}
+}
+
+func TestURLStringQuery(t *testing.T) {
+	rawurl := "https://boratanrikulu.dev/search?z=another+query&q=a%20lovely+query&z=again&sig=abc%2Fdef"
+
+	// Unmodified queries are reproduced byte-for-byte.
+	u, err := NewURL(rawurl)
+	if err != nil {
+		t.Fatalf("Error occur: %s - %s", err, rawurl)
+	}
+	if u.String() != rawurl {
+		t.Fatalf("String is wrong: Wanted: \"%s\" - Got: \"%s\"", rawurl, u.String())
+	}
+
+	// A modified parameter is escaped, the rest are kept.
+	u.QueryParams[1].Value = "a better query"
+	wanted := "https://boratanrikulu.dev/search?z=another+query&q=a+better+query&z=again&sig=abc%2Fdef"
+	if u.String() != wanted {
+		t.Fatalf("String is wrong: Wanted: \"%s\" - Got: \"%s\"", wanted, u.String())
+	}
+
+	// Queries is used when only it is changed.
+	u, err = NewURL(rawurl)
+	if err != nil {
+		t.Fatalf("Error occur: %s - %s", err, rawurl)
+	}
+	u.Queries = map[string][]string{"page": {"2"}}
+	wanted = "https://boratanrikulu.dev/search?page=2"
+	if u.String() != wanted {
+		t.Fatalf("String is wrong: Wanted: \"%s\" - Got: \"%s\"", wanted, u.String())
+	}
+}
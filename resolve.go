@@ -0,0 +1,34 @@
+package url
+
+import neturl "net/url"
+
+// ResolveReference resolves the reference against the base URL as RFC 3986
+// section 5 describes and returns the result as a new URL. The reference may
+// be relative, e.g. "../img/a.png", "//cdn.example.com/x.js" or "?page=2".
+// It is a shorthand for DefaultParser().ResolveReference(base, ref).
+//
+// Example Usage:
+//
+// base, _ := NewURL("https://boratanrikulu.dev/blog/archlinux-install.html")
+// u, _ := ResolveReference(base, "../img/a.png")
+// fmt.Println(u.String()) // "https://boratanrikulu.dev/img/a.png"
+// fmt.Println(u.Domain)   // "boratanrikulu"
+func ResolveReference(base *URL, ref string) (*URL, error) {
+	return defaultParser.ResolveReference(base, ref)
+}
+
+// ResolveReference resolves the reference against the base URL and parses
+// the result on the list of the Parser. If the base is nil, the reference is
+// parsed by itself, so a relative one fails with ReasonMissingScheme.
+// source: https://tools.ietf.org/html/rfc3986#section-5.2
+func (p *Parser) ResolveReference(base *URL, ref string) (*URL, error) {
+	r, err := neturl.Parse(ref)
+	if err != nil {
+		return nil, newParseError(ref, ComponentURL, ReasonMalformed)
+	}
+	if base == nil {
+		return p.Parse(ref)
+	}
+
+	return p.Parse(base.AsNetURL().ResolveReference(r).String())
+}
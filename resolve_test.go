@@ -0,0 +1,178 @@
+package url
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResolveReference(t *testing.T) {
+	// source: https://tools.ietf.org/html/rfc3986#section-5.4
+	// The host "a" of the examples is replaced with "a.com" and "g" with "g.com",
+	// since a URL must have a registrable domain. "g:h" does not have a host,
+	// so it can not be resolved to a URL.
+	base, err := NewURL("http://a.com/b/c/d;p?q")
+	if err != nil {
+		t.Fatalf("Error occur: %s", err)
+	}
+
+	var testValues = []struct {
+		Ref    string
+		Wanted string
+	}{
+		// Normal Examples
+		{Ref: "g", Wanted: "http://a.com/b/c/g"},
+		{Ref: "./g", Wanted: "http://a.com/b/c/g"},
+		{Ref: "g/", Wanted: "http://a.com/b/c/g/"},
+		{Ref: "/g", Wanted: "http://a.com/g"},
+		{Ref: "//g.com", Wanted: "http://g.com"},
+		{Ref: "?y", Wanted: "http://a.com/b/c/d;p?y"},
+		{Ref: "g?y", Wanted: "http://a.com/b/c/g?y"},
+		{Ref: "#s", Wanted: "http://a.com/b/c/d;p?q#s"},
+		{Ref: "g#s", Wanted: "http://a.com/b/c/g#s"},
+		{Ref: "g?y#s", Wanted: "http://a.com/b/c/g?y#s"},
+		{Ref: ";x", Wanted: "http://a.com/b/c/;x"},
+		{Ref: "g;x", Wanted: "http://a.com/b/c/g;x"},
+		{Ref: "g;x?y#s", Wanted: "http://a.com/b/c/g;x?y#s"},
+		{Ref: "", Wanted: "http://a.com/b/c/d;p?q"},
+		{Ref: ".", Wanted: "http://a.com/b/c/"},
+		{Ref: "./", Wanted: "http://a.com/b/c/"},
+		{Ref: "..", Wanted: "http://a.com/b/"},
+		{Ref: "../", Wanted: "http://a.com/b/"},
+		{Ref: "../g", Wanted: "http://a.com/b/g"},
+		{Ref: "../..", Wanted: "http://a.com/"},
+		{Ref: "../../", Wanted: "http://a.com/"},
+		{Ref: "../../g", Wanted: "http://a.com/g"},
+
+		// Abnormal Examples
+		{Ref: "../../../g", Wanted: "http://a.com/g"},
+		{Ref: "../../../../g", Wanted: "http://a.com/g"},
+		{Ref: "/./g", Wanted: "http://a.com/g"},
+		{Ref: "/../g", Wanted: "http://a.com/g"},
+		{Ref: "g.", Wanted: "http://a.com/b/c/g."},
+		{Ref: ".g", Wanted: "http://a.com/b/c/.g"},
+		{Ref: "g..", Wanted: "http://a.com/b/c/g.."},
+		{Ref: "..g", Wanted: "http://a.com/b/c/..g"},
+		{Ref: "./../g", Wanted: "http://a.com/b/g"},
+		{Ref: "./g/.", Wanted: "http://a.com/b/c/g/"},
+		{Ref: "g/./h", Wanted: "http://a.com/b/c/g/h"},
+		{Ref: "g/../h", Wanted: "http://a.com/b/c/h"},
+		{Ref: "g;x=1/./y", Wanted: "http://a.com/b/c/g;x=1/y"},
+		{Ref: "g;x=1/../y", Wanted: "http://a.com/b/c/y"},
+		{Ref: "g?y/./x", Wanted: "http://a.com/b/c/g?y/./x"},
+		{Ref: "g?y/../x", Wanted: "http://a.com/b/c/g?y/../x"},
+		{Ref: "g#s/./x", Wanted: "http://a.com/b/c/g#s/./x"},
+		{Ref: "g#s/../x", Wanted: "http://a.com/b/c/g#s/../x"},
+		{Ref: "http:g", Wanted: "http:g"},
+	}
+
+	for _, testValue := range testValues {
+		u, err := ResolveReference(base, testValue.Ref)
+		if testValue.Wanted == "http:g" {
+			// "http:g" is resolved strictly and does not have a host.
+			if !errors.Is(err, ErrMissingHost) {
+				t.Fatalf("[%s] Error must be ErrMissingHost: Got: %v", testValue.Ref, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Ref)
+		}
+
+		if got := u.String(); got != testValue.Wanted {
+			t.Fatalf("[%s] Resolved URL is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Ref, testValue.Wanted, got)
+		}
+	}
+}
+
+func TestResolveReferenceQuery(t *testing.T) {
+	// The query of the base is kept byte-for-byte, even if it can not be
+	// parsed into Queries.
+	base, err := NewURL("https://boratanrikulu.dev/search?q=%zz&page=2")
+	if err != nil {
+		t.Fatalf("Error occur: %s", err)
+	}
+
+	u, err := ResolveReference(base, "#results")
+	if err != nil {
+		t.Fatalf("Error occur: %s", err)
+	}
+	if wanted := "https://boratanrikulu.dev/search?q=%zz&page=2#results"; u.String() != wanted {
+		t.Fatalf("Resolved URL is wrong: Wanted: \"%s\" - Got: \"%s\"", wanted, u.String())
+	}
+}
+
+func TestResolveReferenceNilBase(t *testing.T) {
+	var testValues = []struct {
+		Ref          string
+		Wanted       string
+		WantedReason Reason
+	}{
+		{Ref: "https://boratanrikulu.dev/img/a.png", Wanted: "https://boratanrikulu.dev/img/a.png"},
+		{Ref: "../img/a.png", WantedReason: ReasonMissingScheme},
+		{Ref: "//cdn.example.co.uk/x.js", WantedReason: ReasonMissingScheme},
+		{Ref: "?page=2", WantedReason: ReasonMissingScheme},
+	}
+
+	for _, testValue := range testValues {
+		u, err := ResolveReference(nil, testValue.Ref)
+		if testValue.WantedReason != "" {
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("[%s] Error must be a ParseError: Got: %T", testValue.Ref, err)
+			}
+			if parseErr.Reason != testValue.WantedReason {
+				t.Fatalf("[%s] Reason is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Ref, testValue.WantedReason, parseErr.Reason)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Ref)
+		}
+
+		if got := u.String(); got != testValue.Wanted {
+			t.Fatalf("[%s] Resolved URL is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Ref, testValue.Wanted, got)
+		}
+	}
+}
+
+func TestResolveReferenceParts(t *testing.T) {
+	base, err := NewURL("https://blog.boratanrikulu.dev/blog/archlinux-install.html?q=1")
+	if err != nil {
+		t.Fatalf("Error occur: %s", err)
+	}
+
+	var testValues = []struct {
+		Ref              string
+		WantedFullDomain string
+		WantedDomain     string
+		WantedPath       string
+		ShouldFail       bool
+	}{
+		{Ref: "../img/a.png", WantedFullDomain: "blog.boratanrikulu.dev", WantedDomain: "boratanrikulu", WantedPath: "/img/a.png"},
+		{Ref: "//cdn.example.co.uk/x.js", WantedFullDomain: "cdn.example.co.uk", WantedDomain: "example", WantedPath: "/x.js"},
+		{Ref: "?page=2", WantedFullDomain: "blog.boratanrikulu.dev", WantedDomain: "boratanrikulu", WantedPath: "/blog/archlinux-install.html"},
+		{Ref: "https://api.seo.do/v1", WantedFullDomain: "api.seo.do", WantedDomain: "seo", WantedPath: "/v1"},
+		{Ref: "//localhost/x.js", WantedFullDomain: "localhost", WantedDomain: "localhost", WantedPath: "/x.js"},
+		{Ref: "//boratanrikulu/x.js", ShouldFail: true},
+		{Ref: "http://[::1", ShouldFail: true},
+	}
+
+	for _, testValue := range testValues {
+		u, err := ResolveReference(base, testValue.Ref)
+		if testValue.ShouldFail {
+			if err == nil {
+				t.Fatalf("[%s] Error must be occurred, but did not", testValue.Ref)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Ref)
+		}
+
+		got := []string{u.FullDomain, u.Domain, u.Path}
+		wanted := []string{testValue.WantedFullDomain, testValue.WantedDomain, testValue.WantedPath}
+		if !equalStringSlice(wanted, got) {
+			t.Fatalf("[%s] Resolved URL is wrong: Wanted: %q - Got: %q", testValue.Ref, wanted, got)
+		}
+	}
+}
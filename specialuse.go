@@ -0,0 +1,91 @@
+package url
+
+import "strings"
+
+// SpecialUse is the kind of a special-use domain name, which is reserved for
+// a special purpose and is not delegated in the global DNS, e.g. "localhost"
+// or "printer.local".
+// source: https://www.iana.org/assignments/special-use-domain-names/special-use-domain-names.xhtml
+type SpecialUse string
+
+const (
+	// SpecialUseNone is for a host that is not a special-use domain name.
+	SpecialUseNone SpecialUse = ""
+	// SpecialUseLoopback is for "localhost" and its subdomains, which resolve
+	// to the loopback addresses (RFC 6761).
+	SpecialUseLoopback SpecialUse = "loopback"
+	// SpecialUseMulticastDNS is for the ".local" names that are resolved by
+	// Multicast DNS on the local link (RFC 6762).
+	SpecialUseMulticastDNS SpecialUse = "multicast-dns"
+	// SpecialUseTesting is for the ".test" names (RFC 6761).
+	SpecialUseTesting SpecialUse = "testing"
+	// SpecialUseDocumentation is for the ".example" names and "example.com",
+	// "example.net" and "example.org" (RFC 6761).
+	SpecialUseDocumentation SpecialUse = "documentation"
+	// SpecialUseInvalid is for the ".invalid" names, which never exist (RFC 6761).
+	SpecialUseInvalid SpecialUse = "invalid"
+	// SpecialUseOnion is for the ".onion" names of the Tor hidden services (RFC 7686).
+	SpecialUseOnion SpecialUse = "onion"
+	// SpecialUseAlternative is for the ".alt" names of the non-DNS name
+	// systems (RFC 9476).
+	SpecialUseAlternative SpecialUse = "alternative"
+	// SpecialUseHomeNetwork is for the "home.arpa" names of the residential
+	// networks (RFC 8375).
+	SpecialUseHomeNetwork SpecialUse = "home-network"
+	// SpecialUsePrivateNetwork is for the ".internal" names that ICANN
+	// reserved for the private networks.
+	SpecialUsePrivateNetwork SpecialUse = "private-network"
+	// SpecialUseInfrastructure is for the ".arpa" names that the protocols
+	// use, e.g. "in-addr.arpa" and "resolver.arpa".
+	SpecialUseInfrastructure SpecialUse = "infrastructure"
+	// SpecialUsePrivate is for the private suffixes that are registered by
+	// WithPrivateSuffixes, e.g. "corp.example".
+	SpecialUsePrivate SpecialUse = "private"
+)
+
+// specialUseDomains includes the special-use domain names with their kinds.
+// Their subdomains are special-use domain names as well.
+// source: https://www.iana.org/assignments/special-use-domain-names/special-use-domain-names.xhtml
+var specialUseDomains = map[string]SpecialUse{
+	"localhost":     SpecialUseLoopback,
+	"local":         SpecialUseMulticastDNS,
+	"test":          SpecialUseTesting,
+	"example":       SpecialUseDocumentation,
+	"example.com":   SpecialUseDocumentation,
+	"example.net":   SpecialUseDocumentation,
+	"example.org":   SpecialUseDocumentation,
+	"invalid":       SpecialUseInvalid,
+	"onion":         SpecialUseOnion,
+	"alt":           SpecialUseAlternative,
+	"home.arpa":     SpecialUseHomeNetwork,
+	"internal":      SpecialUsePrivateNetwork,
+	"in-addr.arpa":  SpecialUseInfrastructure,
+	"ip6.arpa":      SpecialUseInfrastructure,
+	"ipv4only.arpa": SpecialUseInfrastructure,
+	"resolver.arpa": SpecialUseInfrastructure,
+	"service.arpa":  SpecialUseInfrastructure,
+	"6tisch.arpa":   SpecialUseInfrastructure,
+}
+
+// specialUse returns the kind of the special-use domain name that the host
+// is or is under, and the number of labels of that name. The private suffixes
+// of the Parser are preferred, then the longest name is matched.
+func (p *Parser) specialUse(host string) (SpecialUse, int) {
+	kind, count := SpecialUseNone, 0
+	labels := strings.Count(host, ".") + 1
+	for start := 0; ; labels-- {
+		name := host[start:]
+		if p.privateSuffixes[name] {
+			return SpecialUsePrivate, labels
+		}
+		if found, ok := specialUseDomains[name]; ok && kind == SpecialUseNone {
+			kind, count = found, labels
+		}
+
+		i := strings.IndexByte(name, '.')
+		if i < 0 {
+			return kind, count
+		}
+		start += i + 1
+	}
+}
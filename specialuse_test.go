@@ -0,0 +1,85 @@
+package url
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSpecialUse(t *testing.T) {
+	var testValues = []struct {
+		Input            string
+		WantedSpecialUse SpecialUse
+		WantedDomain     string
+		WantedSuffix     string
+	}{
+		{Input: "http://localhost:8080", WantedSpecialUse: SpecialUseLoopback, WantedDomain: "localhost", WantedSuffix: ""},
+		{Input: "http://api.localhost", WantedSpecialUse: SpecialUseLoopback, WantedDomain: "api", WantedSuffix: "localhost"},
+		{Input: "http://printer.local/status", WantedSpecialUse: SpecialUseMulticastDNS, WantedDomain: "printer", WantedSuffix: "local"},
+		{Input: "https://app.test", WantedSpecialUse: SpecialUseTesting, WantedDomain: "app", WantedSuffix: "test"},
+		{Input: "https://foo.internal", WantedSpecialUse: SpecialUsePrivateNetwork, WantedDomain: "foo", WantedSuffix: "internal"},
+		{Input: "http://3g2upl4pq6kufc4m.onion", WantedSpecialUse: SpecialUseOnion, WantedDomain: "3g2upl4pq6kufc4m", WantedSuffix: "onion"},
+		{Input: "https://www.example.com", WantedSpecialUse: SpecialUseDocumentation, WantedDomain: "example", WantedSuffix: "com"},
+		{Input: "https://nothing.invalid", WantedSpecialUse: SpecialUseInvalid, WantedDomain: "nothing", WantedSuffix: "invalid"},
+		{Input: "https://router.home.arpa", WantedSpecialUse: SpecialUseHomeNetwork, WantedDomain: "home", WantedSuffix: "arpa"},
+		{Input: "https://boratanrikulu.dev", WantedSpecialUse: SpecialUseNone, WantedDomain: "boratanrikulu", WantedSuffix: "dev"},
+		{Input: "https://examples.com", WantedSpecialUse: SpecialUseNone, WantedDomain: "examples", WantedSuffix: "com"},
+	}
+
+	for _, testValue := range testValues {
+		u, err := NewURL(testValue.Input)
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Input)
+		}
+
+		if u.SpecialUse != testValue.WantedSpecialUse {
+			t.Fatalf("[%s] SpecialUse is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Input, testValue.WantedSpecialUse, u.SpecialUse)
+		}
+		if u.Domain != testValue.WantedDomain || u.Suffix != testValue.WantedSuffix {
+			t.Fatalf("[%s] Domain is wrong: Wanted: \"%s\" \"%s\" - Got: \"%s\" \"%s\"", testValue.Input, testValue.WantedDomain, testValue.WantedSuffix, u.Domain, u.Suffix)
+		}
+
+		_, err = NewParser(WithoutSpecialUse()).Parse(testValue.Input)
+		if rejected := errors.Is(err, ErrSpecialUse); rejected != (testValue.WantedSpecialUse != SpecialUseNone) {
+			t.Fatalf("[%s] Special-use domain name must be rejected: \"%t\" - Got: \"%v\"", testValue.Input, !rejected, err)
+		}
+	}
+}
+
+func TestPrivateSuffixes(t *testing.T) {
+	p := NewParser(WithPrivateSuffixes("corp.example", "Intranet.ACME."), WithoutSpecialUse())
+
+	var testValues = []struct {
+		Input            string
+		WantedSubdomains []string
+		WantedDomain     string
+		WantedSuffix     string
+		WantedSpecialUse SpecialUse
+		ShouldFail       bool
+	}{
+		{Input: "https://wiki.intranet.corp.example", WantedSubdomains: []string{"wiki"}, WantedDomain: "intranet", WantedSuffix: "corp.example", WantedSpecialUse: SpecialUsePrivate},
+		{Input: "https://jira.intranet.acme/browse", WantedSubdomains: []string{}, WantedDomain: "jira", WantedSuffix: "intranet.acme", WantedSpecialUse: SpecialUsePrivate},
+		{Input: "https://boratanrikulu.dev", WantedSubdomains: []string{}, WantedDomain: "boratanrikulu", WantedSuffix: "dev", WantedSpecialUse: SpecialUseNone},
+		{Input: "https://wiki.other.example", ShouldFail: true},
+		{Input: "https://acme", ShouldFail: true},
+	}
+
+	for _, testValue := range testValues {
+		u, err := p.Parse(testValue.Input)
+		if testValue.ShouldFail {
+			if err == nil {
+				t.Fatalf("[%s] Error must be occurred, but did not", testValue.Input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Input)
+		}
+
+		if !equalStringSlice(u.Subdomains, testValue.WantedSubdomains) || u.Domain != testValue.WantedDomain || u.Suffix != testValue.WantedSuffix {
+			t.Fatalf("[%s] Split is wrong: Wanted: %q \"%s\" \"%s\" - Got: %q \"%s\" \"%s\"", testValue.Input, testValue.WantedSubdomains, testValue.WantedDomain, testValue.WantedSuffix, u.Subdomains, u.Domain, u.Suffix)
+		}
+		if u.SpecialUse != testValue.WantedSpecialUse {
+			t.Fatalf("[%s] SpecialUse is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Input, testValue.WantedSpecialUse, u.SpecialUse)
+		}
+	}
+}
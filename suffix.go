@@ -0,0 +1,258 @@
+package url
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/zeoagency/url/internal/iana"
+)
+
+// List is a suffix list that a Parser resolves the public suffixes by.
+//
+// It can be created from a Public Suffix List (public_suffix_list.dat) or
+// an IANA list (tlds-alpha-by-domain.txt) by ParseList. Each TLD of an
+// IANA list is taken as an ICANN rule.
+type List struct {
+	// root is the root of the label trie of the rules. The trie is walked
+	// from the TLD to the left, one label per level.
+	root *suffixNode
+
+	// countryCodes includes the country-code TLDs of the list.
+	countryCodes map[string]bool
+
+	// ianaUpdated is the "Last Updated" date of the IANA list.
+	ianaVersion string
+	ianaUpdated string
+	pslVersion  string
+}
+
+// suffixNode is a node of the label trie of a List.
+type suffixNode struct {
+	children map[string]*suffixNode
+
+	// normal, wildcard and exception tell which kind of rules end at the
+	// node, e.g. "co.uk", "*.ck" and "!www.ck" for the nodes "uk.co", "ck"
+	// and "ck.www".
+	normal    ruleSection
+	wildcard  ruleSection
+	exception ruleSection
+}
+
+// ruleSection tells in which sections of the list a rule exists.
+type ruleSection uint8
+
+const (
+	icannSection ruleSection = 1 << iota
+	privateSection
+)
+
+// in tells whether the rule exists in the sections that are looked for.
+// It returns the section of the rule, preferring the ICANN one.
+func (s ruleSection) in(icannOnly bool) (ok bool, icann bool) {
+	if s&icannSection != 0 {
+		return true, true
+	}
+	return s&privateSection != 0 && !icannOnly, false
+}
+
+// defaultList is the list that is generated into tables.go.
+var defaultList = newList(icannSuffixes, privateSuffixes, countryTopLevelDomains, ianaVersion, ianaUpdated, pslVersion)
+
+// newList returns a list for the given rules and country-code TLDs.
+func newList(icann, private, countryCodes []string, ianaVersion, ianaUpdated, pslVersion string) *List {
+	l := &List{
+		root:         &suffixNode{},
+		countryCodes: make(map[string]bool),
+		ianaVersion:  ianaVersion,
+		ianaUpdated:  ianaUpdated,
+		pslVersion:   pslVersion,
+	}
+	for _, rule := range private {
+		l.add(rule, false)
+	}
+	for _, rule := range icann {
+		l.add(rule, true)
+	}
+	for _, tld := range countryCodes {
+		l.countryCodes[tld] = true
+	}
+	return l
+}
+
+// ParseList reads a Public Suffix List or an IANA list from r.
+//
+// Lines starting with "//" are taken as Public Suffix List comments, which may
+// mark the ICANN and PRIVATE sections and the VERSION of the list. Lines starting
+// with "#" are taken as IANA comments, the first of which is the version line,
+// e.g. "# Version 2021012800, Last Updated Thu Jan 28 07:07:02 2021 UTC".
+// Rules outside of the sections are taken as ICANN rules.
+func ParseList(r io.Reader) (*List, error) {
+	var icann, private []string
+	var ianaVersion, ianaUpdated, pslVersion, pslCommit string
+	isPrivate := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "//"):
+			switch {
+			case strings.HasPrefix(line, "// VERSION:"):
+				pslVersion = strings.TrimSpace(strings.TrimPrefix(line, "// VERSION:"))
+			case strings.HasPrefix(line, "// COMMIT:"):
+				pslCommit = strings.TrimSpace(strings.TrimPrefix(line, "// COMMIT:"))
+			case strings.HasPrefix(line, "// ===BEGIN PRIVATE DOMAINS==="):
+				isPrivate = true
+			case strings.HasPrefix(line, "// ===END PRIVATE DOMAINS==="):
+				isPrivate = false
+			}
+		case strings.HasPrefix(line, "#"):
+			if ianaVersion == "" {
+				ianaVersion, ianaUpdated = iana.ParseVersion(line)
+			}
+		default:
+			// Only the first field of a line is the rule.
+			rule := strings.ToLower(strings.Fields(line)[0])
+			if isPrivate {
+				private = append(private, rule)
+			} else {
+				icann = append(icann, rule)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(icann) == 0 {
+		return nil, errors.New("There is no ICANN rule in the list.")
+	}
+	if pslCommit != "" {
+		pslVersion = strings.TrimSpace(pslVersion + " " + pslCommit)
+	}
+
+	// Some TLDs only have wildcard rules (e.g. "*.ck"), so the last label
+	// of each rule is used to find the country-code TLDs.
+	var countryCodes []string
+	for _, rule := range icann {
+		tld := rule[strings.LastIndex(rule, ".")+1:]
+		if iana.IsCountryCode(tld) {
+			countryCodes = append(countryCodes, tld)
+		}
+	}
+
+	return newList(icann, private, countryCodes, ianaVersion, ianaUpdated, pslVersion), nil
+}
+
+// Version returns the versions of the lists that the list was created from,
+// e.g. "iana:2021012800 psl:20230209.2326". A version that is not known is
+// left out.
+func (l *List) Version() string {
+	var versions []string
+	if l.ianaVersion != "" {
+		versions = append(versions, "iana:"+l.ianaVersion)
+	}
+	if l.pslVersion != "" {
+		versions = append(versions, "psl:"+l.pslVersion)
+	}
+	return strings.Join(versions, " ")
+}
+
+// IANAUpdated returns the "Last Updated" date of the IANA list that the list
+// was created from, e.g. "Thu Jan 28 07:07:02 2021 UTC". It is empty if the
+// list was not created from an IANA list.
+func (l *List) IANAUpdated() string {
+	return l.ianaUpdated
+}
+
+// add puts the rule into the trie by looking at its prefix.
+func (l *List) add(rule string, icann bool) {
+	section := privateSection
+	if icann {
+		section = icannSection
+	}
+
+	rule = strings.ToLower(rule)
+	name := strings.TrimPrefix(strings.TrimPrefix(rule, "!"), "*.")
+
+	// Hosts are matched in their ASCII forms, so are the rules.
+	// A rule that does not have a valid ASCII form can not match any host.
+	name, err := toASCII(name)
+	if err != nil {
+		return
+	}
+
+	n := l.root
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		child := n.children[labels[i]]
+		if child == nil {
+			child = &suffixNode{}
+			if n.children == nil {
+				n.children = make(map[string]*suffixNode)
+			}
+			n.children[labels[i]] = child
+		}
+		n = child
+	}
+
+	switch {
+	case strings.HasPrefix(rule, "!"):
+		n.exception |= section
+	case strings.HasPrefix(rule, "*."):
+		n.wildcard |= section
+	default:
+		n.normal |= section
+	}
+}
+
+// match returns how many labels from the end of the given labels are
+// the public suffix and whether the prevailing rule is an ICANN one.
+//
+// It follows the algorithm on https://publicsuffix.org/list/ except the
+// default "*" rule; a suffix that is not on the list is not matched and
+// 0 is returned. If icannOnly is true, private rules are ignored.
+func (l *List) match(labels []string, icannOnly bool) (int, bool) {
+	count, icann := 0, false
+	n := l.root
+	for i := len(labels) - 1; i >= 0; i-- {
+		// A wildcard rule of the parent matches any label.
+		if ok, isICANN := n.wildcard.in(icannOnly); ok {
+			count, icann = len(labels)-i, isICANN
+		}
+
+		n = n.children[labels[i]]
+		if n == nil {
+			break
+		}
+
+		// An exception rule always prevails.
+		if ok, isICANN := n.exception.in(icannOnly); ok {
+			return len(labels) - i - 1, isICANN
+		}
+		if ok, isICANN := n.normal.in(icannOnly); ok {
+			count, icann = len(labels)-i, isICANN
+		}
+	}
+	return count, icann
+}
+
+// split returns the number of labels of the public suffix for the given labels.
+//
+// If the whole host is a suffix from the private section (e.g. "github.io"),
+// the host is split by ICANN rules only since it is a site on its own.
+func (l *List) split(labels []string, icannOnly bool) (int, bool) {
+	count, icann := l.match(labels, icannOnly)
+	if count >= len(labels) && !icann {
+		count, icann = l.match(labels, true)
+	}
+	return count, icann
+}
+
+// ListVersion returns the version of the list that NewURL uses,
+// e.g. "iana:2021012800 psl:20230209.2326".
+func ListVersion() string {
+	return DefaultParser().List().Version()
+}
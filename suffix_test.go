@@ -0,0 +1,33 @@
+package url
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestListMatchAllocs(t *testing.T) {
+	labels := strings.Split("an.awesome.blog.boratanrikulu.com.tr", ".")
+	allocs := testing.AllocsPerRun(100, func() {
+		defaultList.split(labels, false)
+	})
+	if allocs != 0 {
+		t.Fatalf("Suffix matching must not allocate: Got: %v allocs", allocs)
+	}
+}
+
+func BenchmarkListMatch(b *testing.B) {
+	hosts := make([][]string, 0, len(benchmarkURLs))
+	for _, rawurl := range benchmarkURLs {
+		u, err := NewURL(rawurl)
+		if err != nil {
+			continue
+		}
+		hosts = append(hosts, strings.Split(u.FullDomain, "."))
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		defaultList.split(hosts[i%len(hosts)], false)
+	}
+}
@@ -0,0 +1,632 @@
+package url
+
+import (
+	"strings"
+
+	"github.com/zeoagency/url/internal/iana"
+)
+
+// TLDCategory is the category of a TLD by the IANA Root Zone Database.
+// source: https://www.iana.org/domains/root/db
+type TLDCategory string
+
+const (
+	// TLDGeneric is for a generic TLD that is open to everyone, e.g. "com" or "dev".
+	TLDGeneric TLDCategory = "generic"
+	// TLDBrand is for a generic TLD that is only used by the brand that
+	// operates it, e.g. "google" or "amazon" (Specification 13 of ICANN).
+	TLDBrand TLDCategory = "brand"
+	// TLDSponsored is for a TLD that is operated for a community by a
+	// sponsor, e.g. "edu", "gov" or "museum".
+	TLDSponsored TLDCategory = "sponsored"
+	// TLDCountryCode is for a TLD of a country or a territory, e.g. "tr" or "рф".
+	TLDCountryCode TLDCategory = "country-code"
+	// TLDGenericRestricted is for a generic TLD that has eligibility rules,
+	// which are "biz", "name" and "pro".
+	TLDGenericRestricted TLDCategory = "generic-restricted"
+	// TLDInfrastructure is for the "arpa" TLD.
+	TLDInfrastructure TLDCategory = "infrastructure"
+	// TLDTest is for the TLDs that are reserved for testing and
+	// documentation, e.g. "test" or "example" (RFC 2606).
+	TLDTest TLDCategory = "test"
+)
+
+// DelegationStatus tells whether a TLD is delegated in the root zone.
+type DelegationStatus string
+
+const (
+	// TLDDelegated is for a TLD that is delegated in the root zone.
+	TLDDelegated DelegationStatus = "delegated"
+	// TLDReserved is for a TLD that is reserved and never delegated, e.g. "test".
+	TLDReserved DelegationStatus = "reserved"
+)
+
+// TopLevelDomain is the metadata of a TLD that TLDInfo returns.
+type TopLevelDomain struct {
+	// TLD and UnicodeTLD are the ASCII and the Unicode forms of the TLD,
+	// e.g. "xn--p1ai" and "рф".
+	TLD        string
+	UnicodeTLD string
+	Category   TLDCategory
+	Status     DelegationStatus
+	// IDN tells whether the TLD is an internationalized one.
+	IDN bool
+	// Operator is the registry operator of a generic TLD that is delegated
+	// by the New gTLD Program, e.g. "Charleston Road Registry Inc." for "dev".
+	// It is empty for the other TLDs.
+	Operator string
+	// CountryCode and Country are the ISO 3166-1 alpha-2 code and the name of
+	// the country or the territory of a country-code TLD, e.g. "GB" and
+	// "United Kingdom" for "uk". They are empty for the other TLDs.
+	CountryCode string
+	Country     string
+}
+
+// TLDInfo returns the metadata of the TLD from the data that is bundled into
+// the package. The TLD can be given in its ASCII or Unicode form, and with or
+// without its leading dot. false is returned if the TLD is not known.
+//
+// Example Usage:
+//
+// info, _ := TLDInfo("uk")
+// fmt.Println(info.Category, info.CountryCode, info.Country) // "country-code" "GB" "United Kingdom"
+func TLDInfo(tld string) (TopLevelDomain, bool) {
+	tld = strings.TrimPrefix(strings.ToLower(tld), ".")
+	unicodeTLD, err := toUnicode(tld)
+	if err != nil || unicodeTLD == "" || strings.Contains(unicodeTLD, ".") {
+		return TopLevelDomain{}, false
+	}
+	tld, err = toASCII(unicodeTLD)
+	if err != nil {
+		return TopLevelDomain{}, false
+	}
+
+	info := TopLevelDomain{
+		TLD:        tld,
+		UnicodeTLD: unicodeTLD,
+		Status:     TLDDelegated,
+		IDN:        tld != unicodeTLD,
+	}
+	code, isCountry := idnCountryTopLevelDomains[unicodeTLD]
+	if iana.IsCountryCode(tld) {
+		code, isCountry = strings.ToUpper(tld), true
+		// The ISO 3166 code of the United Kingdom is "GB".
+		if code == "UK" {
+			code = "GB"
+		}
+	}
+
+	if category, ok := reservedTopLevelDomains[tld]; ok {
+		info.Category, info.Status = category, TLDReserved
+		return info, true
+	}
+
+	switch {
+	case isCountry:
+		if !tldCountryCodes[tld] && !info.IDN {
+			return TopLevelDomain{}, false
+		}
+		info.Category, info.CountryCode, info.Country = TLDCountryCode, code, countryNames[code]
+		return info, true
+	case !tldGenerics[unicodeTLD]:
+		return TopLevelDomain{}, false
+	}
+
+	info.Operator = tldOperators[unicodeTLD]
+	info.Category = TLDGeneric
+	if category, ok := tldCategories[unicodeTLD]; ok {
+		info.Category = category
+	} else if brandTopLevelDomains[unicodeTLD] {
+		info.Category = TLDBrand
+	}
+	return info, true
+}
+
+// TLDInfo returns the metadata of the TLD of the URL, which is the last label
+// of its host, e.g. "uk" for "https://www.bbc.co.uk".
+// false is returned if the host is an IP address or the TLD is not known.
+func (u *URL) TLDInfo() (TopLevelDomain, bool) {
+	if u.HostType != HostDomain {
+		return TopLevelDomain{}, false
+	}
+	host := u.hostname()
+	return TLDInfo(host[strings.LastIndex(host, ".")+1:])
+}
+
+// tldCountryCodes and tldGenerics include the TLDs that are generated into
+// the package, in their ASCII and Unicode forms respectively.
+var (
+	tldCountryCodes = stringSet(countryTopLevelDomains)
+	tldGenerics     = stringSet(topLevelDomains)
+)
+
+// stringSet returns a set of the values.
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+// reservedTopLevelDomains includes the TLDs that are reserved and never
+// delegated with their categories. "onion" is on the Public Suffix List,
+// but it is a special-use domain name of Tor (RFC 7686).
+// source: https://tools.ietf.org/html/rfc2606#section-2
+var reservedTopLevelDomains = map[string]TLDCategory{
+	"example":   TLDTest,
+	"invalid":   TLDTest,
+	"localhost": TLDTest,
+	"test":      TLDTest,
+	"onion":     TLDInfrastructure,
+}
+
+// tldCategories includes the TLDs whose categories are not generic, except
+// the country-code and the brand ones.
+// source: https://www.iana.org/domains/root/db
+var tldCategories = map[string]TLDCategory{
+	"aero":   TLDSponsored,
+	"asia":   TLDSponsored,
+	"cat":    TLDSponsored,
+	"coop":   TLDSponsored,
+	"edu":    TLDSponsored,
+	"gov":    TLDSponsored,
+	"int":    TLDSponsored,
+	"jobs":   TLDSponsored,
+	"mil":    TLDSponsored,
+	"museum": TLDSponsored,
+	"post":   TLDSponsored,
+	"tel":    TLDSponsored,
+	"travel": TLDSponsored,
+	"xxx":    TLDSponsored,
+	"biz":    TLDGenericRestricted,
+	"name":   TLDGenericRestricted,
+	"pro":    TLDGenericRestricted,
+	"arpa":   TLDInfrastructure,
+}
+
+// brandTopLevelDomains includes the generic TLDs that are only used by the
+// brands that operate them (Specification 13 of the Registry Agreement).
+// source: https://www.icann.org/resources/pages/specification-13-2014-06-20-en
+var brandTopLevelDomains = map[string]bool{
+	"aaa":                true,
+	"aarp":               true,
+	"abarth":             true,
+	"abb":                true,
+	"abbott":             true,
+	"abbvie":             true,
+	"abc":                true,
+	"accenture":          true,
+	"aco":                true,
+	"aeg":                true,
+	"aetna":              true,
+	"afl":                true,
+	"agakhan":            true,
+	"aig":                true,
+	"airbus":             true,
+	"airtel":             true,
+	"akdn":               true,
+	"alfaromeo":          true,
+	"alibaba":            true,
+	"alipay":             true,
+	"allfinanz":          true,
+	"allstate":           true,
+	"ally":               true,
+	"alstom":             true,
+	"amazon":             true,
+	"americanexpress":    true,
+	"americanfamily":     true,
+	"amex":               true,
+	"amfam":              true,
+	"amica":              true,
+	"android":            true,
+	"anz":                true,
+	"aol":                true,
+	"apple":              true,
+	"aquarelle":          true,
+	"aramco":             true,
+	"audi":               true,
+	"audible":            true,
+	"auspost":            true,
+	"avianca":            true,
+	"aws":                true,
+	"axa":                true,
+	"azure":              true,
+	"baidu":              true,
+	"banamex":            true,
+	"bananarepublic":     true,
+	"barclaycard":        true,
+	"barclays":           true,
+	"barefoot":           true,
+	"bauhaus":            true,
+	"bbc":                true,
+	"bbt":                true,
+	"bbva":               true,
+	"bcg":                true,
+	"beats":              true,
+	"bentley":            true,
+	"bestbuy":            true,
+	"bharti":             true,
+	"bing":               true,
+	"blockbuster":        true,
+	"bloomberg":          true,
+	"bms":                true,
+	"bmw":                true,
+	"bnpparibas":         true,
+	"boehringer":         true,
+	"bofa":               true,
+	"bosch":              true,
+	"bostik":             true,
+	"bradesco":           true,
+	"bridgestone":        true,
+	"brother":            true,
+	"calvinklein":        true,
+	"canon":              true,
+	"capitalone":         true,
+	"caravan":            true,
+	"cba":                true,
+	"cbn":                true,
+	"cbre":               true,
+	"cbs":                true,
+	"cern":               true,
+	"cfa":                true,
+	"chanel":             true,
+	"chase":              true,
+	"chintai":            true,
+	"chrome":             true,
+	"cipriani":           true,
+	"cisco":              true,
+	"citadel":            true,
+	"citi":               true,
+	"citic":              true,
+	"clinique":           true,
+	"clubmed":            true,
+	"comcast":            true,
+	"commbank":           true,
+	"cookingchannel":     true,
+	"crs":                true,
+	"cuisinella":         true,
+	"dabur":              true,
+	"datsun":             true,
+	"dclk":               true,
+	"dell":               true,
+	"deloitte":           true,
+	"delta":              true,
+	"dhl":                true,
+	"discover":           true,
+	"dish":               true,
+	"dnp":                true,
+	"dtv":                true,
+	"dunlop":             true,
+	"dupont":             true,
+	"dvag":               true,
+	"edeka":              true,
+	"emerck":             true,
+	"epson":              true,
+	"ericsson":           true,
+	"erni":               true,
+	"etisalat":           true,
+	"extraspace":         true,
+	"fage":               true,
+	"fairwinds":          true,
+	"fedex":              true,
+	"ferrari":            true,
+	"ferrero":            true,
+	"fiat":               true,
+	"fidelity":           true,
+	"fido":               true,
+	"firestone":          true,
+	"firmdale":           true,
+	"flickr":             true,
+	"flir":               true,
+	"foodnetwork":        true,
+	"ford":               true,
+	"fox":                true,
+	"fresenius":          true,
+	"frontdoor":          true,
+	"frontier":           true,
+	"ftr":                true,
+	"fujitsu":            true,
+	"gallo":              true,
+	"gallup":             true,
+	"gap":                true,
+	"gea":                true,
+	"genting":            true,
+	"george":             true,
+	"ggee":               true,
+	"gle":                true,
+	"globo":              true,
+	"gmail":              true,
+	"gmo":                true,
+	"gmx":                true,
+	"godaddy":            true,
+	"goldpoint":          true,
+	"goo":                true,
+	"goodyear":           true,
+	"goog":               true,
+	"google":             true,
+	"grainger":           true,
+	"guardian":           true,
+	"gucci":              true,
+	"guge":               true,
+	"hbo":                true,
+	"hdfc":               true,
+	"hdfcbank":           true,
+	"hermes":             true,
+	"hgtv":               true,
+	"hisamitsu":          true,
+	"hitachi":            true,
+	"hkt":                true,
+	"homedepot":          true,
+	"homegoods":          true,
+	"homesense":          true,
+	"honda":              true,
+	"hotmail":            true,
+	"hsbc":               true,
+	"hughes":             true,
+	"hyatt":              true,
+	"hyundai":            true,
+	"ibm":                true,
+	"icbc":               true,
+	"ice":                true,
+	"ieee":               true,
+	"ifm":                true,
+	"ikano":              true,
+	"imamat":             true,
+	"imdb":               true,
+	"infiniti":           true,
+	"intuit":             true,
+	"ipiranga":           true,
+	"ismaili":            true,
+	"itau":               true,
+	"itv":                true,
+	"jaguar":             true,
+	"java":               true,
+	"jcb":                true,
+	"jeep":               true,
+	"jio":                true,
+	"jll":                true,
+	"jmp":                true,
+	"jnj":                true,
+	"jpmorgan":           true,
+	"jprs":               true,
+	"juniper":            true,
+	"kddi":               true,
+	"kerryhotels":        true,
+	"kerrylogistics":     true,
+	"kerryproperties":    true,
+	"kfh":                true,
+	"kia":                true,
+	"kinder":             true,
+	"kindle":             true,
+	"komatsu":            true,
+	"kpmg":               true,
+	"kpn":                true,
+	"kuokgroup":          true,
+	"lacaixa":            true,
+	"lamborghini":        true,
+	"lamer":              true,
+	"lancaster":          true,
+	"lancia":             true,
+	"landrover":          true,
+	"lanxess":            true,
+	"lasalle":            true,
+	"latrobe":            true,
+	"lds":                true,
+	"leclerc":            true,
+	"lefrak":             true,
+	"lego":               true,
+	"lexus":              true,
+	"lidl":               true,
+	"lilly":              true,
+	"lincoln":            true,
+	"linde":              true,
+	"lipsy":              true,
+	"locus":              true,
+	"lotte":              true,
+	"lpl":                true,
+	"lplfinancial":       true,
+	"lundbeck":           true,
+	"macys":              true,
+	"maif":               true,
+	"mango":              true,
+	"marriott":           true,
+	"marshalls":          true,
+	"maserati":           true,
+	"mattel":             true,
+	"mckinsey":           true,
+	"merckmsd":           true,
+	"microsoft":          true,
+	"mini":               true,
+	"mit":                true,
+	"mitsubishi":         true,
+	"mlb":                true,
+	"mma":                true,
+	"monash":             true,
+	"mormon":             true,
+	"moto":               true,
+	"msd":                true,
+	"mtn":                true,
+	"mtr":                true,
+	"nab":                true,
+	"natura":             true,
+	"nba":                true,
+	"nec":                true,
+	"netbank":            true,
+	"netflix":            true,
+	"neustar":            true,
+	"nextdirect":         true,
+	"nfl":                true,
+	"nhk":                true,
+	"nico":               true,
+	"nike":               true,
+	"nikon":              true,
+	"nissan":             true,
+	"nissay":             true,
+	"nokia":              true,
+	"northwesternmutual": true,
+	"norton":             true,
+	"nowtv":              true,
+	"nra":                true,
+	"ntt":                true,
+	"obi":                true,
+	"office":             true,
+	"olayan":             true,
+	"olayangroup":        true,
+	"oldnavy":            true,
+	"ollo":               true,
+	"omega":              true,
+	"oracle":             true,
+	"orange":             true,
+	"otsuka":             true,
+	"ovh":                true,
+	"panasonic":          true,
+	"pccw":               true,
+	"pfizer":             true,
+	"philips":            true,
+	"pictet":             true,
+	"ping":               true,
+	"pioneer":            true,
+	"playstation":        true,
+	"pnc":                true,
+	"pohl":               true,
+	"politie":            true,
+	"pramerica":          true,
+	"praxi":              true,
+	"progressive":        true,
+	"pru":                true,
+	"prudential":         true,
+	"pwc":                true,
+	"quest":              true,
+	"redumbrella":        true,
+	"reliance":           true,
+	"rexroth":            true,
+	"richardli":          true,
+	"ricoh":              true,
+	"ril":                true,
+	"rocher":             true,
+	"rogers":             true,
+	"rwe":                true,
+	"samsclub":           true,
+	"samsung":            true,
+	"sandvik":            true,
+	"sandvikcoromant":    true,
+	"sanofi":             true,
+	"sap":                true,
+	"sas":                true,
+	"saxo":               true,
+	"sbi":                true,
+	"sbs":                true,
+	"sca":                true,
+	"scb":                true,
+	"schaeffler":         true,
+	"schmidt":            true,
+	"schwarz":            true,
+	"seat":               true,
+	"seek":               true,
+	"sener":              true,
+	"seven":              true,
+	"sew":                true,
+	"sfr":                true,
+	"shangrila":          true,
+	"sharp":              true,
+	"shell":              true,
+	"showtime":           true,
+	"sina":               true,
+	"sky":                true,
+	"skype":              true,
+	"sling":              true,
+	"smart":              true,
+	"sncf":               true,
+	"softbank":           true,
+	"sohu":               true,
+	"sony":               true,
+	"stada":              true,
+	"staples":            true,
+	"star":               true,
+	"statebank":          true,
+	"statefarm":          true,
+	"stc":                true,
+	"stcgroup":           true,
+	"suzuki":             true,
+	"swatch":             true,
+	"tab":                true,
+	"tatamotors":         true,
+	"tci":                true,
+	"tdk":                true,
+	"temasek":            true,
+	"teva":               true,
+	"tiaa":               true,
+	"tiffany":            true,
+	"tjmaxx":             true,
+	"tjx":                true,
+	"tkmaxx":             true,
+	"tmall":              true,
+	"toray":              true,
+	"toshiba":            true,
+	"total":              true,
+	"toyota":             true,
+	"travelchannel":      true,
+	"travelers":          true,
+	"travelersinsurance": true,
+	"tui":                true,
+	"tvs":                true,
+	"ubank":              true,
+	"ubs":                true,
+	"unicom":             true,
+	"uol":                true,
+	"ups":                true,
+	"vanguard":           true,
+	"verisign":           true,
+	"vig":                true,
+	"viking":             true,
+	"virgin":             true,
+	"visa":               true,
+	"vivo":               true,
+	"volkswagen":         true,
+	"volvo":              true,
+	"walmart":            true,
+	"walter":             true,
+	"weather":            true,
+	"weatherchannel":     true,
+	"weber":              true,
+	"weir":               true,
+	"williamhill":        true,
+	"windows":            true,
+	"wme":                true,
+	"wolterskluwer":      true,
+	"woodside":           true,
+	"xbox":               true,
+	"xerox":              true,
+	"xfinity":            true,
+	"yahoo":              true,
+	"yamaxun":            true,
+	"yandex":             true,
+	"yodobashi":          true,
+	"youtube":            true,
+	"zappos":             true,
+	"zara":               true,
+	"zuerich":            true,
+	"vermögensberater":   true,
+	"vermögensberatung":  true,
+	"اتصالات":            true,
+	"ارامكو":             true,
+	"العليان":            true,
+	"بيتك":               true,
+	"همراه":              true,
+	"アマゾン":               true,
+	"グーグル":               true,
+	"中信":                 true,
+	"亚马逊":                true,
+	"嘉里":                 true,
+	"嘉里大酒店":              true,
+	"微博":                 true,
+	"淡马锡":                true,
+	"点看":                 true,
+	"联通":                 true,
+	"谷歌":                 true,
+	"電訊盈科":               true,
+	"飞利浦":                true,
+	"香格里拉":               true,
+	"삼성":                 true,
+}
@@ -0,0 +1,131 @@
+package url
+
+import "testing"
+
+func TestTLDInfo(t *testing.T) {
+	var testValues = []struct {
+		Input      string
+		Wanted     TopLevelDomain
+		ShouldFail bool
+	}{
+		{
+			Input:  "com",
+			Wanted: TopLevelDomain{TLD: "com", UnicodeTLD: "com", Category: TLDGeneric, Status: TLDDelegated},
+		},
+		{
+			Input:  ".DEV",
+			Wanted: TopLevelDomain{TLD: "dev", UnicodeTLD: "dev", Category: TLDGeneric, Status: TLDDelegated, Operator: "Charleston Road Registry Inc."},
+		},
+		{
+			Input:  "google",
+			Wanted: TopLevelDomain{TLD: "google", UnicodeTLD: "google", Category: TLDBrand, Status: TLDDelegated, Operator: "Charleston Road Registry Inc."},
+		},
+		{
+			Input:  "アマゾン",
+			Wanted: TopLevelDomain{TLD: "xn--cckwcxetd", UnicodeTLD: "アマゾン", Category: TLDBrand, Status: TLDDelegated, IDN: true, Operator: "Amazon Registry Services, Inc."},
+		},
+		{
+			Input:  "museum",
+			Wanted: TopLevelDomain{TLD: "museum", UnicodeTLD: "museum", Category: TLDSponsored, Status: TLDDelegated},
+		},
+		{
+			Input:  "biz",
+			Wanted: TopLevelDomain{TLD: "biz", UnicodeTLD: "biz", Category: TLDGenericRestricted, Status: TLDDelegated},
+		},
+		{
+			Input:  "arpa",
+			Wanted: TopLevelDomain{TLD: "arpa", UnicodeTLD: "arpa", Category: TLDInfrastructure, Status: TLDDelegated},
+		},
+		{
+			Input:  "tr",
+			Wanted: TopLevelDomain{TLD: "tr", UnicodeTLD: "tr", Category: TLDCountryCode, Status: TLDDelegated, CountryCode: "TR", Country: "Türkiye"},
+		},
+		{
+			Input:  "uk",
+			Wanted: TopLevelDomain{TLD: "uk", UnicodeTLD: "uk", Category: TLDCountryCode, Status: TLDDelegated, CountryCode: "GB", Country: "United Kingdom"},
+		},
+		{
+			Input:  "xn--p1ai",
+			Wanted: TopLevelDomain{TLD: "xn--p1ai", UnicodeTLD: "рф", Category: TLDCountryCode, Status: TLDDelegated, IDN: true, CountryCode: "RU", Country: "Russia"},
+		},
+		{
+			Input:  "香港",
+			Wanted: TopLevelDomain{TLD: "xn--j6w193g", UnicodeTLD: "香港", Category: TLDCountryCode, Status: TLDDelegated, IDN: true, CountryCode: "HK", Country: "Hong Kong"},
+		},
+		{
+			Input:  "test",
+			Wanted: TopLevelDomain{TLD: "test", UnicodeTLD: "test", Category: TLDTest, Status: TLDReserved},
+		},
+		{Input: "randomwrongtld", ShouldFail: true},
+		{Input: "zz", ShouldFail: true},
+		{Input: "co.uk", ShouldFail: true},
+		{Input: "", ShouldFail: true},
+	}
+
+	for _, testValue := range testValues {
+		info, ok := TLDInfo(testValue.Input)
+		if testValue.ShouldFail {
+			if ok {
+				t.Fatalf("[%s] TLD must not be known, but it is: %+v", testValue.Input, info)
+			}
+			continue
+		}
+		if !ok {
+			t.Fatalf("[%s] TLD must be known, but it is not", testValue.Input)
+		}
+
+		if info != testValue.Wanted {
+			t.Fatalf("[%s] TLD info is wrong: Wanted: %+v - Got: %+v", testValue.Input, testValue.Wanted, info)
+		}
+	}
+}
+
+func TestURLTLDInfo(t *testing.T) {
+	var testValues = []struct {
+		Input          string
+		WantedTLD      string
+		WantedCategory TLDCategory
+		ShouldFail     bool
+	}{
+		{Input: "https://an.awesome.blog.boratanrikulu.com.tr", WantedTLD: "tr", WantedCategory: TLDCountryCode},
+		{Input: "https://boratanrikulu.dev", WantedTLD: "dev", WantedCategory: TLDGeneric},
+		{Input: "https://www.bar.blogspot.co.uk", WantedTLD: "uk", WantedCategory: TLDCountryCode},
+		{Input: "https://пример.рф", WantedTLD: "xn--p1ai", WantedCategory: TLDCountryCode},
+		{Input: "https://app.test", WantedTLD: "test", WantedCategory: TLDTest},
+		{Input: "https://192.168.1.10/admin", ShouldFail: true},
+		{Input: "http://printer.local", ShouldFail: true},
+	}
+
+	for _, testValue := range testValues {
+		u, err := NewURL(testValue.Input)
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Input)
+		}
+
+		info, ok := u.TLDInfo()
+		if ok == testValue.ShouldFail {
+			t.Fatalf("[%s] TLD must be known: \"%t\" - Got: \"%t\"", testValue.Input, !testValue.ShouldFail, ok)
+		}
+		if info.TLD != testValue.WantedTLD || info.Category != testValue.WantedCategory {
+			t.Fatalf("[%s] TLD info is wrong: Wanted: \"%s\" \"%s\" - Got: \"%s\" \"%s\"", testValue.Input, testValue.WantedTLD, testValue.WantedCategory, info.TLD, info.Category)
+		}
+	}
+}
+
+func TestTLDTables(t *testing.T) {
+	for tld := range brandTopLevelDomains {
+		if !tldGenerics[tld] {
+			t.Fatalf("[%s] Brand TLD is not on the TLD list", tld)
+		}
+	}
+	for tld, code := range idnCountryTopLevelDomains {
+		if countryNames[code] == "" {
+			t.Fatalf("[%s] Country of the IDN TLD does not have a name: %s", tld, code)
+		}
+	}
+	for _, tld := range countryTopLevelDomains {
+		if info, _ := TLDInfo(tld); info.Country == "" {
+			t.Fatalf("[%s] Country of the TLD does not have a name", tld)
+		}
+	}
+}
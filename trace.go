@@ -0,0 +1,229 @@
+package url
+
+import (
+	"context"
+	"errors"
+	"html"
+	nethttp "net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// maxTracedBody is the number of the bytes of an HTML page that are searched
+// for the meta refresh and canonical tags.
+const maxTracedBody = 64 << 10
+
+var (
+	// ErrRedirectLoop is returned when a redirect leads to a URL that is
+	// already visited.
+	ErrRedirectLoop = errors.New("There is a redirect loop.")
+	// ErrCanonicalLoop is returned when a redirect loop has a canonical link.
+	ErrCanonicalLoop = errors.New("There is a canonical loop.")
+)
+
+// TraceOptions configures TraceRedirects.
+type TraceOptions struct {
+	// Client is the client that the requests are sent with. It is
+	// DefaultHTTPClient by default. The redirect policy of a *http.Client is
+	// not used, since the redirects are followed by TraceRedirects.
+	Client HTTPClient
+	// Transport is used by a new client if Client is not given.
+	Transport nethttp.RoundTripper
+	// Timeout is the timeout of each request. It is 5 seconds by default.
+	Timeout time.Duration
+	// UserAgent is sent with the requests. It is DefaultUserAgent by default.
+	UserAgent string
+	// MaxRedirects is the number of the redirects to follow. It is 10 by default.
+	MaxRedirects int
+	// NoMetaRefresh makes the meta refresh redirects not followed.
+	NoMetaRefresh bool
+	// FollowCanonical makes the canonical links that point to another URL
+	// followed like redirects, so the canonical loops can be detected.
+	FollowCanonical bool
+}
+
+// Trace is the result of TraceRedirects.
+type Trace struct {
+	// Hops includes every response in their order. The last one is the final response.
+	Hops       []Hop
+	FinalURL   string
+	StatusCode int
+	// Loop tells whether the chain leads back to a URL that is already visited.
+	Loop       bool
+	ErrorClass ErrorClass
+	Err        error
+}
+
+// TraceRedirects sends GET requests to the URL and follows its HTTP and meta
+// refresh redirects, and returns every hop of the chain with its status code,
+// Location and latency. It stops at a loop or after too many redirects.
+//
+// Example Usage:
+//
+// u, _ := NewURL("http://boratanrikulu.dev")
+// trace := TraceRedirects(ctx, u, TraceOptions{})
+// fmt.Println(trace.Hops[0].StatusCode, trace.Hops[0].Location) // 301 "https://boratanrikulu.dev/"
+// fmt.Println(trace.FinalURL, trace.StatusCode)                  // "https://boratanrikulu.dev/" 200
+func TraceRedirects(ctx context.Context, u *URL, opts TraceOptions) Trace {
+	client := newClient(opts.Client, opts.Transport)
+	maxRedirects := opts.MaxRedirects
+	if maxRedirects == 0 {
+		maxRedirects = defaultMaxRedirects
+	}
+
+	var trace Trace
+	visited := make(map[string]int)
+	rawurl := u.requestURL()
+	for {
+		if i, ok := visited[rawurl]; ok {
+			trace.Loop, trace.ErrorClass, trace.Err = true, ErrorClassRedirect, ErrRedirectLoop
+			for _, hop := range trace.Hops[i:] {
+				if hop.Kind == RedirectCanonical {
+					trace.Err = ErrCanonicalLoop
+				}
+			}
+			break
+		}
+		if len(trace.Hops) > maxRedirects {
+			trace.ErrorClass, trace.Err = ErrorClassRedirect, ErrTooManyRedirects
+			break
+		}
+		visited[rawurl] = len(trace.Hops)
+
+		hop, resp, body, err := fetch(ctx, client, nethttp.MethodGet, rawurl, opts.UserAgent, opts.Timeout, maxTracedBody)
+		if err != nil {
+			trace.ErrorClass, trace.Err = classifyError(err), err
+			break
+		}
+		if hop.Kind == "" {
+			inspectPage(&hop, resp, body, opts)
+		}
+
+		trace.Hops = append(trace.Hops, hop)
+		trace.FinalURL, trace.StatusCode = hop.URL, hop.StatusCode
+		if hop.Location == "" {
+			break
+		}
+		rawurl = hop.Location
+	}
+	return trace
+}
+
+// inspectPage sets the meta refresh redirect and the canonical link of the hop.
+func inspectPage(hop *Hop, resp *nethttp.Response, body []byte, opts TraceOptions) {
+	var refresh, canonical string
+	if value := resp.Header.Get("Refresh"); value != "" {
+		refresh = value
+	}
+	for _, link := range resp.Header["Link"] {
+		if href := canonicalLink(link); href != "" {
+			canonical = href
+		}
+	}
+
+	if strings.Contains(resp.Header.Get("Content-Type"), "html") {
+		for _, tag := range htmlTags(body) {
+			switch {
+			case tag.name == "meta" && strings.EqualFold(tag.attrs["http-equiv"], "refresh") && refresh == "":
+				refresh = tag.attrs["content"]
+			case tag.name == "link" && hasToken(tag.attrs["rel"], "canonical") && canonical == "":
+				canonical = tag.attrs["href"]
+			}
+		}
+	}
+
+	if canonical != "" {
+		hop.Canonical, _ = resolveLocation(hop.URL, canonical)
+	}
+	if location := refreshURL(refresh); location != "" && !opts.NoMetaRefresh {
+		if resolved, err := resolveLocation(hop.URL, location); err == nil && resolved != hop.URL {
+			hop.Location, hop.Kind = resolved, RedirectMetaRefresh
+			return
+		}
+	}
+	if opts.FollowCanonical && hop.Canonical != "" && hop.Canonical != hop.URL {
+		hop.Location, hop.Kind = hop.Canonical, RedirectCanonical
+	}
+}
+
+// refreshURL returns the URL of the value of a Refresh header or a meta
+// refresh tag, e.g. "0; url=https://example.com/".
+// source: https://html.spec.whatwg.org/multipage/semantics.html#attr-meta-http-equiv-refresh
+func refreshURL(value string) string {
+	i := strings.IndexAny(value, ";,")
+	if i < 0 {
+		return ""
+	}
+	value = strings.TrimSpace(value[i+1:])
+	if len(value) >= 3 && strings.EqualFold(value[:3], "url") {
+		if rest := strings.TrimSpace(value[3:]); strings.HasPrefix(rest, "=") {
+			value = strings.TrimSpace(rest[1:])
+		}
+	}
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			value = value[1 : end+1]
+		}
+	}
+	return value
+}
+
+// canonicalLink returns the canonical URL of a Link header, if it has one,
+// e.g. `<https://example.com/>; rel="canonical"`.
+// source: https://tools.ietf.org/html/rfc8288#section-3
+func canonicalLink(header string) string {
+	for _, link := range strings.Split(header, ",") {
+		parts := strings.Split(link, ";")
+		target := strings.TrimSpace(parts[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+		for _, param := range parts[1:] {
+			key, value := queryKey(strings.TrimSpace(param)), ""
+			if i := strings.Index(param, "="); i >= 0 {
+				value = strings.Trim(strings.TrimSpace(param[i+1:]), "\"")
+			}
+			if strings.EqualFold(key, "rel") && hasToken(value, "canonical") {
+				return target[1 : len(target)-1]
+			}
+		}
+	}
+	return ""
+}
+
+// hasToken tells whether the space-separated list has the token.
+func hasToken(list, token string) bool {
+	for _, t := range strings.Fields(list) {
+		if strings.EqualFold(t, token) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	htmlTagPattern  = regexp.MustCompile(`(?is)<(meta|link)\b[^>]*>`)
+	htmlAttrPattern = regexp.MustCompile(`([a-zA-Z_:][-a-zA-Z0-9_:.]*)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+)
+
+// htmlTag is a meta or a link tag with its attributes.
+type htmlTag struct {
+	name  string
+	attrs map[string]string
+}
+
+// htmlTags returns the meta and the link tags of the HTML page.
+// The names of the tags and the attributes are lowercased.
+func htmlTags(body []byte) []htmlTag {
+	var tags []htmlTag
+	for _, match := range htmlTagPattern.FindAllSubmatch(body, -1) {
+		tag := htmlTag{name: strings.ToLower(string(match[1])), attrs: make(map[string]string)}
+		for _, attr := range htmlAttrPattern.FindAllSubmatch(match[0], -1) {
+			value := string(attr[2]) + string(attr[3]) + string(attr[4])
+			tag.attrs[strings.ToLower(string(attr[1]))] = html.UnescapeString(value)
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
@@ -0,0 +1,200 @@
+package url
+
+import (
+	"context"
+	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// traceHandler returns the handler of the test server for TraceRedirects.
+func traceHandler() nethttp.Handler {
+	mux := nethttp.NewServeMux()
+	redirect := func(path, location string, code int) {
+		mux.HandleFunc(path, func(w nethttp.ResponseWriter, r *nethttp.Request) {
+			nethttp.Redirect(w, r, location, code)
+		})
+	}
+	page := func(path, head string) {
+		mux.HandleFunc(path, func(w nethttp.ResponseWriter, r *nethttp.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte("<!DOCTYPE html><html><head>" + head + "</head><body></body></html>"))
+		})
+	}
+
+	redirect("/301", "/302", nethttp.StatusMovedPermanently)
+	redirect("/302", "/307", nethttp.StatusFound)
+	redirect("/307", "/308", nethttp.StatusTemporaryRedirect)
+	redirect("/308", "/final", nethttp.StatusPermanentRedirect)
+	page("/final", `<link rel="canonical" href="/final">`)
+
+	redirect("/loop-a", "/loop-b", nethttp.StatusFound)
+	redirect("/loop-b", "/loop-a", nethttp.StatusFound)
+	mux.HandleFunc("/endless/", func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		nethttp.Redirect(w, r, r.URL.Path+"x", nethttp.StatusFound)
+	})
+
+	page("/meta", `<META HTTP-EQUIV="Refresh" CONTENT="0; URL='/final'">`)
+	mux.HandleFunc("/refresh-header", func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		w.Header().Set("Refresh", "3;url=/final")
+	})
+
+	page("/canonical-a", `<link rel="stylesheet" href="a.css"><link href="/canonical-b" rel="canonical">`)
+	redirect("/canonical-b", "/canonical-a", nethttp.StatusMovedPermanently)
+	mux.HandleFunc("/canonical-header", func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		w.Header().Set("Link", `</style.css>; rel="preload", </final>; rel="canonical"`)
+	})
+	return mux
+}
+
+func TestTraceRedirects(t *testing.T) {
+	server := httptest.NewServer(traceHandler())
+	defer server.Close()
+
+	type hop struct {
+		Path       string
+		StatusCode int
+		Kind       RedirectKind
+	}
+	var testValues = []struct {
+		Path            string
+		Options         TraceOptions
+		WantedHops      []hop
+		WantedCanonical string
+		WantedLoop      bool
+		WantedErr       error
+	}{
+		{
+			Path: "/301",
+			WantedHops: []hop{
+				{"/301", 301, RedirectHTTP}, {"/302", 302, RedirectHTTP}, {"/307", 307, RedirectHTTP},
+				{"/308", 308, RedirectHTTP}, {"/final", 200, ""},
+			},
+			WantedCanonical: "/final",
+		},
+		{
+			Path:       "/meta",
+			WantedHops: []hop{{"/meta", 200, RedirectMetaRefresh}, {"/final", 200, ""}},
+		},
+		{
+			Path:       "/meta",
+			Options:    TraceOptions{NoMetaRefresh: true},
+			WantedHops: []hop{{"/meta", 200, ""}},
+		},
+		{
+			Path:       "/refresh-header",
+			WantedHops: []hop{{"/refresh-header", 200, RedirectMetaRefresh}, {"/final", 200, ""}},
+		},
+		{
+			Path:       "/loop-a",
+			WantedHops: []hop{{"/loop-a", 302, RedirectHTTP}, {"/loop-b", 302, RedirectHTTP}},
+			WantedLoop: true,
+			WantedErr:  ErrRedirectLoop,
+		},
+		{
+			Path:            "/canonical-a",
+			WantedHops:      []hop{{"/canonical-a", 200, ""}},
+			WantedCanonical: "/canonical-b",
+		},
+		{
+			Path:            "/canonical-a",
+			Options:         TraceOptions{FollowCanonical: true},
+			WantedHops:      []hop{{"/canonical-a", 200, RedirectCanonical}, {"/canonical-b", 301, RedirectHTTP}},
+			WantedCanonical: "/canonical-b",
+			WantedLoop:      true,
+			WantedErr:       ErrCanonicalLoop,
+		},
+		{
+			Path:            "/canonical-header",
+			Options:         TraceOptions{FollowCanonical: true},
+			WantedHops:      []hop{{"/canonical-header", 200, RedirectCanonical}, {"/final", 200, ""}},
+			WantedCanonical: "/final",
+		},
+		{
+			Path:      "/endless/",
+			Options:   TraceOptions{MaxRedirects: 5},
+			WantedErr: ErrTooManyRedirects,
+		},
+	}
+
+	for _, testValue := range testValues {
+		u, err := NewURL(server.URL + testValue.Path)
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Path)
+		}
+
+		trace := TraceRedirects(context.Background(), u, testValue.Options)
+		if !errors.Is(trace.Err, testValue.WantedErr) {
+			t.Fatalf("[%s] Err is wrong: Wanted: \"%v\" - Got: \"%v\"", testValue.Path, testValue.WantedErr, trace.Err)
+		}
+		if trace.Loop != testValue.WantedLoop {
+			t.Fatalf("[%s] Loop is wrong: Wanted: \"%t\" - Got: \"%t\"", testValue.Path, testValue.WantedLoop, trace.Loop)
+		}
+		if testValue.WantedErr == ErrTooManyRedirects {
+			if len(trace.Hops) != 6 {
+				t.Fatalf("[%s] Hops are wrong: Wanted: \"%d\" - Got: \"%d\"", testValue.Path, 6, len(trace.Hops))
+			}
+			continue
+		}
+
+		if len(trace.Hops) != len(testValue.WantedHops) {
+			t.Fatalf("[%s] Hops are wrong: Wanted: %v - Got: %+v", testValue.Path, testValue.WantedHops, trace.Hops)
+		}
+		for i, wanted := range testValue.WantedHops {
+			got := trace.Hops[i]
+			if got.URL != server.URL+wanted.Path || got.StatusCode != wanted.StatusCode || got.Kind != wanted.Kind {
+				t.Fatalf("[%s] Hop %d is wrong: Wanted: %v - Got: %+v", testValue.Path, i, wanted, got)
+			}
+			if i+1 < len(testValue.WantedHops) && got.Location != server.URL+testValue.WantedHops[i+1].Path {
+				t.Fatalf("[%s] Location of hop %d is wrong: Got: \"%s\"", testValue.Path, i, got.Location)
+			}
+		}
+
+		last := trace.Hops[len(trace.Hops)-1]
+		if testValue.WantedCanonical != "" && trace.Hops[0].Canonical != server.URL+testValue.WantedCanonical && last.Canonical != server.URL+testValue.WantedCanonical {
+			t.Fatalf("[%s] Canonical is wrong: Wanted: \"%s\" - Got: %+v", testValue.Path, testValue.WantedCanonical, trace.Hops)
+		}
+		if !trace.Loop && (trace.FinalURL != last.URL || trace.StatusCode != last.StatusCode) {
+			t.Fatalf("[%s] Final response is wrong: Got: \"%s\" %d", testValue.Path, trace.FinalURL, trace.StatusCode)
+		}
+	}
+}
+
+func TestHopPermanent(t *testing.T) {
+	var testValues = []struct {
+		Hop    Hop
+		Wanted bool
+	}{
+		{Hop: Hop{StatusCode: 301, Kind: RedirectHTTP}, Wanted: true},
+		{Hop: Hop{StatusCode: 308, Kind: RedirectHTTP}, Wanted: true},
+		{Hop: Hop{StatusCode: 302, Kind: RedirectHTTP}, Wanted: false},
+		{Hop: Hop{StatusCode: 307, Kind: RedirectHTTP}, Wanted: false},
+		{Hop: Hop{StatusCode: 200, Kind: RedirectMetaRefresh}, Wanted: false},
+	}
+
+	for _, testValue := range testValues {
+		if got := testValue.Hop.Permanent(); got != testValue.Wanted {
+			t.Fatalf("[%d] Permanent is wrong: Wanted: \"%t\" - Got: \"%t\"", testValue.Hop.StatusCode, testValue.Wanted, got)
+		}
+	}
+}
+
+func TestRefreshURL(t *testing.T) {
+	var testValues = []struct {
+		Input  string
+		Wanted string
+	}{
+		{Input: "0; url=https://example.com/", Wanted: "https://example.com/"},
+		{Input: "5;URL='/a b'", Wanted: "/a b"},
+		{Input: "0 ; Url = \"/x\"", Wanted: "/x"},
+		{Input: "0,/x", Wanted: "/x"},
+		{Input: "30", Wanted: ""},
+	}
+
+	for _, testValue := range testValues {
+		if got := refreshURL(testValue.Input); got != testValue.Wanted {
+			t.Fatalf("[%s] URL is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Input, testValue.Wanted, got)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package url
+
+import (
+	"path"
+	"strings"
+
+	neturl "net/url"
+)
+
+// trackingParameters includes the query parameters that are used for tracking
+// and session handling. They are matched case-insensitively and may have "*"
+// wildcards, e.g. "utm_*".
+var trackingParameters = []string{
+	// Campaigns
+	"utm_*", "mtm_*", "pk_*", "mkt_tok", "_openstat", "s_kwcid",
+	// Click identifiers
+	"gclid", "gclsrc", "dclid", "gbraid", "wbraid", "fbclid", "msclkid", "yclid",
+	"twclid", "ttclid", "igshid", "li_fat_id", "epik",
+	// Analytics and e-mail marketing
+	"_ga", "_gl", "_hsenc", "_hsmi", "__hssc", "__hstc", "__hsfp", "hsctatracking",
+	"mc_cid", "mc_eid", "oly_anon_id", "oly_enc_id", "vero_conv", "vero_id",
+	// Sessions
+	"jsessionid", "phpsessid", "aspsessionid*", "cfid", "cftoken", "sessionid",
+}
+
+// StripOptions configures StripTracking.
+type StripOptions struct {
+	// Deny includes the parameters to remove on top of the default ones,
+	// e.g. "utm_*", "gclid", "fbclid" and "jsessionid".
+	Deny []string
+	// Allow includes the parameters to keep even if they are denied.
+	// Both lists may have "*" wildcards and are matched case-insensitively.
+	Allow []string
+	// NoDefaults makes only the Deny list used, without the default ones.
+	NoDefaults bool
+}
+
+// StripTracking returns the URL without its tracking parameters and the keys of
+// the removed parameters. The URL is assembled from the current values of its
+// fields like String does, so the changes to Queries or QueryParams are kept.
+// The parameters are removed from the query and from the path parameters of
+// the segments of the path, e.g. ";jsessionid=1234". The order and the
+// encoding of the kept parameters are not changed. The URL itself is not modified.
+//
+// Example Usage:
+//
+// u, _ := NewURL("https://boratanrikulu.dev/blog;jsessionid=1234?utm_source=twitter&page=2&fbclid=abc")
+// stripped, removed := u.StripTracking(StripOptions{})
+// fmt.Println(stripped) // "https://boratanrikulu.dev/blog?page=2"
+// fmt.Println(removed)  // ["jsessionid", "utm_source", "fbclid"]
+func (u *URL) StripTracking(opts StripOptions) (string, []string) {
+	deny := opts.Deny
+	if !opts.NoDefaults {
+		deny = append(append([]string{}, trackingParameters...), opts.Deny...)
+	}
+
+	var removed []string
+	seen := make(map[string]bool)
+	strip := func(key string) bool {
+		if !matchParameter(key, deny) || matchParameter(key, opts.Allow) {
+			return false
+		}
+		if !seen[key] {
+			seen[key] = true
+			removed = append(removed, key)
+		}
+		return true
+	}
+
+	// The path parameters come first, as they do in the URL.
+	segments := strings.Split(u.Path, "/")
+	for i, segment := range segments {
+		params := strings.Split(segment, ";")
+		kept := params[:1]
+		for _, param := range params[1:] {
+			key := param
+			if j := strings.Index(param, "="); j >= 0 {
+				key = param[:j]
+			}
+			if unescaped, err := neturl.PathUnescape(key); err == nil {
+				key = unescaped
+			}
+			if !strip(key) {
+				kept = append(kept, param)
+			}
+		}
+		segments[i] = strings.Join(kept, ";")
+	}
+
+	query := parseQuery(u.encodeQuery())
+	kept := make(Query, 0, len(query))
+	for _, param := range query {
+		if (param.RawKey == "" && !param.HasValue) || !strip(param.Key) {
+			kept = append(kept, param)
+		}
+	}
+
+	if len(removed) == 0 {
+		return u.String(), nil
+	}
+
+	stripped := *u
+	stripped.Path = strings.Join(segments, "/")
+	stripped.QueryParams = kept
+	if strings.Trim(kept.Encode(QueryEncodingRaw), "&") == "" {
+		stripped.QueryParams = Query{}
+	}
+	stripped.Queries = stripped.QueryParams.Values()
+	stripped.rawQuery = stripped.QueryParams.Encode(QueryEncodingRaw)
+	return stripped.String(), removed
+}
+
+// matchParameter tells whether the key matches any of the patterns.
+func matchParameter(key string, patterns []string) bool {
+	key = strings.ToLower(key)
+	for _, pattern := range patterns {
+		if ok, err := path.Match(strings.ToLower(pattern), key); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
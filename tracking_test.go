@@ -0,0 +1,99 @@
+package url
+
+import "testing"
+
+func TestStripTracking(t *testing.T) {
+	var testValues = []struct {
+		Input         string
+		Options       StripOptions
+		Wanted        string
+		WantedRemoved []string
+	}{
+		{
+			Input:         "https://boratanrikulu.dev/blog?utm_source=twitter&page=2&fbclid=abc",
+			Wanted:        "https://boratanrikulu.dev/blog?page=2",
+			WantedRemoved: []string{"utm_source", "fbclid"},
+		},
+		{
+			Input:         "https://boratanrikulu.dev/blog?UTM_Medium=email&utm_campaign=x&gclid=1&msclkid=2&mc_eid=3&_ga=4&PHPSESSID=5&jsessionid=6#top",
+			Wanted:        "https://boratanrikulu.dev/blog#top",
+			WantedRemoved: []string{"UTM_Medium", "utm_campaign", "gclid", "msclkid", "mc_eid", "_ga", "PHPSESSID", "jsessionid"},
+		},
+		{
+			Input:         "https://boratanrikulu.dev/blog?q=a+lovely%20query&utm_source=x&q=again",
+			Wanted:        "https://boratanrikulu.dev/blog?q=a+lovely%20query&q=again",
+			WantedRemoved: []string{"utm_source"},
+		},
+		{
+			Input:         "https://boratanrikulu.dev/blog?page=2",
+			Wanted:        "https://boratanrikulu.dev/blog?page=2",
+			WantedRemoved: nil,
+		},
+		{
+			Input:         "https://boratanrikulu.dev/blog",
+			Wanted:        "https://boratanrikulu.dev/blog",
+			WantedRemoved: nil,
+		},
+		{
+			Input:         "https://boratanrikulu.dev/blog?utm_source=x&utm_source=y&ref=home",
+			Options:       StripOptions{Deny: []string{"ref"}},
+			Wanted:        "https://boratanrikulu.dev/blog",
+			WantedRemoved: []string{"utm_source", "ref"},
+		},
+		{
+			Input:         "https://boratanrikulu.dev/blog?utm_source=x&utm_campaign=y&gclid=z",
+			Options:       StripOptions{Allow: []string{"utm_campaign"}},
+			Wanted:        "https://boratanrikulu.dev/blog?utm_campaign=y",
+			WantedRemoved: []string{"utm_source", "gclid"},
+		},
+		{
+			Input:         "https://boratanrikulu.dev/blog?utm_source=x&sort=asc&session_a=1&session_b=2",
+			Options:       StripOptions{Deny: []string{"session_*"}, NoDefaults: true},
+			Wanted:        "https://boratanrikulu.dev/blog?utm_source=x&sort=asc",
+			WantedRemoved: []string{"session_a", "session_b"},
+		},
+		{
+			Input:         "https://boratanrikulu.dev/blog?utm%5Fsource=x&page=1",
+			Wanted:        "https://boratanrikulu.dev/blog?page=1",
+			WantedRemoved: []string{"utm_source"},
+		},
+		{
+			Input:         "https://boratanrikulu.dev/shop;jsessionid=1234/cart;JSESSIONID=5678;v=2?utm_source=x&page=2",
+			Wanted:        "https://boratanrikulu.dev/shop/cart;v=2?page=2",
+			WantedRemoved: []string{"jsessionid", "JSESSIONID", "utm_source"},
+		},
+	}
+
+	for _, testValue := range testValues {
+		u, err := NewURL(testValue.Input)
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Input)
+		}
+
+		got, removed := u.StripTracking(testValue.Options)
+		if got != testValue.Wanted {
+			t.Fatalf("[%s] Stripped URL is wrong: Wanted: \"%s\" - Got: \"%s\"", testValue.Input, testValue.Wanted, got)
+		}
+
+		if !equalStringSlice(testValue.WantedRemoved, removed) {
+			t.Fatalf("[%s] Removed keys are wrong: Wanted: %q - Got: %q", testValue.Input, testValue.WantedRemoved, removed)
+		}
+	}
+}
+
+func TestStripTrackingModified(t *testing.T) {
+	u, err := NewURL("https://boratanrikulu.dev/blog?utm_source=twitter&page=2")
+	if err != nil {
+		t.Fatalf("Error occur: %s", err)
+	}
+	u.Path = "/blog/archlinux-install.html"
+	u.Queries["fbclid"] = []string{"abc"}
+
+	got, removed := u.StripTracking(StripOptions{})
+	if wanted := "https://boratanrikulu.dev/blog/archlinux-install.html?page=2"; got != wanted {
+		t.Fatalf("Stripped URL is wrong: Wanted: \"%s\" - Got: \"%s\"", wanted, got)
+	}
+	if wanted := []string{"fbclid", "utm_source"}; !equalStringSlice(wanted, removed) {
+		t.Fatalf("Removed keys are wrong: Wanted: %q - Got: %q", wanted, removed)
+	}
+}
@@ -1,148 +1,379 @@
 package url
 
+//go:generate go run ./internal/gen -iana data/tlds-alpha-by-domain.txt -psl data/public_suffix_list.dat -psl-version 20230209.2326 -output tables.go
+
 import (
-	"errors"
-	"net"
-	nethttp "net/http"
+	"context"
 	neturl "net/url"
 	"strings"
-	"time"
 )
 
 // URL is a struct that you can extract each element of an URL.
 //
+// The public suffix of the host is resolved by the rules of the Public Suffix List
+// (https://publicsuffix.org). When the suffix ends with a country-code TLD, the
+// country-code part is kept in CTLD and the rest of the suffix in TLD.
+// So TLD is not always the top-level label of the host: it is "github" for
+// "foo.github.io" and "blogspot.co" for "bar.blogspot.co.uk". Use Suffix or
+// PublicSuffix for the whole public suffix, and TLDInfo for the top-level label.
+//
+// Internationalized domain names are accepted in both their Unicode and
+// ASCII (Punycode) forms.
+//
+// If the host is an IPv4 or IPv6 address, it is kept in FullDomain and
+// HostType tells which one it is. Domain, TLD and CTLD are left empty.
+//
+// Special-use domain names, such as "localhost" and "printer.local", are
+// accepted even if they are not on the list, and SpecialUse tells their kind.
+// A single-label one, e.g. "localhost", is kept in Domain without a suffix.
+//
+// Port is the port that is given in the URL. PortOrDefault returns the
+// default port of the scheme when it is not given.
+//
 // It works like this:
 //
-// Given URL:    "https://an.awesome.blog.boratanrikulu.dev.tr/blog/archlinux-install.html?q=a+lovely+query&z=another+query"
+// Given URL:    "https://an.awesome.blog.boratanrikulu.com.tr/blog/archlinux-install.html?q=a+lovely+query&z=another+query"
 // Result:
-//   SUB_DOMAINS: an.awesome.blog
-//   DOMAIN:      boratanrikulu
-//   TLD:         dev
-//   C-TLD:       tr
-//   Full Domain: an.awesome.blog.boratanrikulu.dev.tr
-//   Path:        /blog/archlinux-install.html
-//   Queries:     q:a+lovely+query, z:another+query
+//
+//	SUB_DOMAINS: an.awesome.blog
+//	DOMAIN:      boratanrikulu
+//	TLD:         com
+//	C-TLD:       tr
+//	Suffix:      com.tr
+//	Full Domain: an.awesome.blog.boratanrikulu.com.tr
+//	Path:        /blog/archlinux-install.html
+//	Queries:     q:a+lovely+query, z:another+query
 //
 // Example Usage:
 //
-// u := NewURL("https://an.awesome.blog.boratanrikulu.dev.tr/blog/archlinux-install.html?q=a+lovely+query&z=an+other+query")
-// fmt.Println(u.TLD // "dev"
+// u := NewURL("https://an.awesome.blog.boratanrikulu.com.tr/blog/archlinux-install.html?q=a+lovely+query&z=an+other+query")
+// fmt.Println(u.TLD // "com"
 // fmt.Println(u.CTLD) // "tr"
 // fmt.Println(u.Queries) // "[q:[a lovely query] z:[another query]]"
 type URL struct {
 	Rawurl     string
+	Scheme     string
+	Username   string
+	Password   string
+	HostType   HostType
 	Subdomains []string
 	Domain     string
-	TLD        string
-	CTLD       string
+	// TLD is the public suffix without CTLD, e.g. "com" for "com.tr" and
+	// "github" for "github.io". It is the whole suffix if CTLD is empty.
+	TLD string
+	// CTLD is the country-code TLD that ends the public suffix, e.g. "tr".
+	CTLD string
+	// Suffix is the public suffix of the host, e.g. "com.tr" or "github.io".
+	Suffix     string
+	ICANN      bool
+	SpecialUse SpecialUse
 	FullDomain string
+	Port       string
 	Path       string
 	Queries    map[string][]string
+	Fragment   string
+
+	// QueryParams keeps the parameters of the query in their order with their
+	// raw and decoded forms. String uses it as long as Queries is not changed,
+	// so the original query is reproduced byte-for-byte.
+	QueryParams Query
+
+	// Unicode forms of FullDomain, Domain and Suffix.
+	// The host parts above are kept in their ASCII forms,
+	// e.g. "xn--mnchen-3ya.de" and "münchen.de".
+	UnicodeFullDomain string
+	UnicodeDomain     string
+	UnicodeSuffix     string
+
+	// rawQuery is the query as it is parsed, to find out which one of
+	// Queries and QueryParams is changed.
+	rawQuery string
+	// geoTargets is the table of GeoTarget of the Parser that parsed the URL.
+	geoTargets map[string]string
 }
 
 // NewURL returns a new URL by validating it.
+// It is a shorthand for DefaultParser().Parse(rawurl).
 func NewURL(rawurl string) (*URL, error) {
+	return defaultParser.Parse(rawurl)
+}
+
+// Parse returns a new URL by validating it on the list of the Parser.
+func (p *Parser) Parse(rawurl string) (*URL, error) {
+	list := p.List()
+
 	u, err := neturl.Parse(rawurl)
-	if err != nil || u.Scheme == "" {
-		return nil, errors.New("That's not a valid URL.")
+	if err != nil {
+		return nil, newParseError(rawurl, ComponentURL, ReasonMalformed)
+	}
+	if u.Scheme == "" {
+		return nil, newParseError(rawurl, ComponentScheme, ReasonMissingScheme)
 	}
+	if u.Hostname() == "" {
+		return nil, newParseError(rawurl, ComponentHost, ReasonMissingHost)
+	}
+
+	password, _ := u.User.Password()
+	url := &URL{
+		Rawurl:   rawurl,
+		Scheme:   u.Scheme,
+		Username: u.User.Username(),
+		Password: password,
+		Port:     u.Port(),
+		Path:     u.EscapedPath(),
+		Queries:  u.Query(),
+		Fragment: u.Fragment,
 
-	parts := strings.Split(u.Hostname(), ".")
-	if len(parts) < 2 {
-		return nil, errors.New("That's not a valid URL.")
+		QueryParams: parseQuery(u.RawQuery),
+		rawQuery:    u.RawQuery,
+		geoTargets:  p.geoTargets,
 	}
 
-	tldCount := 1
-	if len(parts) == 2 {
-		if !(stringSliceContains(topLevelDomains, parts[1]) || stringSliceContains(countryTopLevelDomains, parts[1])) {
-			return nil, errors.New("That's not a valid URL.")
-		}
-		tldCount = 1
-	}
-	if len(parts) >= 3 {
-		if stringSliceContains(topLevelDomains, parts[len(parts)-1]) {
-			tldCount = 1
-		} else if stringSliceContains(countryTopLevelDomains, parts[len(parts)-1]) {
-			if stringSliceContains(topLevelDomains, parts[len(parts)-2]) {
-				tldCount = 2
-			} else {
-				tldCount = 1
-			}
-		} else {
-			return nil, errors.New("That's not a valid URL.")
-		}
+	// IP addresses do not have any domain part.
+	if hostType, ip := parseIPHost(u.Hostname()); hostType != HostDomain {
+		url.HostType = hostType
+		url.Subdomains = []string{}
+		url.FullDomain = ip
+		url.UnicodeFullDomain = ip
+		return url, nil
 	}
 
-	// TLD
-	tld := parts[len(parts)-tldCount]
+	host, err := toASCII(u.Hostname())
+	if err != nil {
+		return nil, newParseError(rawurl, ComponentHost, ReasonInvalidHost)
+	}
+
+	labels := strings.Split(host, ".")
+	parts, reason := validateLabels(labels, p.hostPolicy)
+	if reason != "" {
+		return nil, newParseError(rawurl, ComponentHost, reason)
+	}
+	// The trailing dot or the empty labels are removed by the policy.
+	if len(parts) != len(labels) {
+		host = strings.Join(parts, ".")
+	}
 
-	// Country TLD
-	var ctld string
-	if tldCount == 2 {
-		ctld = parts[len(parts)-1]
+	specialUse, specialCount := p.specialUse(host)
+	if p.rejectSpecialUse && specialUse != SpecialUseNone && specialUse != SpecialUsePrivate {
+		return nil, newParseError(rawurl, ComponentHost, ReasonSpecialUse)
+	}
+	if len(parts) < 2 && specialUse == SpecialUseNone {
+		return nil, newParseError(rawurl, ComponentHost, ReasonSingleLabelHost)
+	}
+
+	suffixCount, icann := list.split(parts, p.icannOnly)
+	switch {
+	case specialUse == SpecialUsePrivate:
+		suffixCount, icann = specialCount, false
+	case specialUse != SpecialUseNone && suffixCount == 0:
+		suffixCount = specialCount
+	}
+	// A special-use domain name is a host on its own, e.g. "localhost",
+	// so the suffix is left for its last labels, if there is any.
+	if specialUse != SpecialUseNone && suffixCount >= len(parts) {
+		suffixCount = len(parts) - 1
+	}
+	if suffixCount == 0 && specialUse == SpecialUseNone {
+		return nil, newParseError(rawurl, ComponentSuffix, ReasonUnknownSuffix)
+	}
+	if suffixCount >= len(parts) {
+		return nil, newParseError(rawurl, ComponentHost, ReasonPublicSuffix)
+	}
+	suffix := parts[len(parts)-suffixCount:]
+
+	// TLD and Country TLD
+	url.TLD = strings.Join(suffix, ".")
+	if len(suffix) >= 2 && list.countryCodes[suffix[len(suffix)-1]] {
+		url.TLD = strings.Join(suffix[:len(suffix)-1], ".")
+		url.CTLD = suffix[len(suffix)-1]
 	}
 
 	// Domain
-	domain := parts[len(parts)-tldCount-1]
-	subDomains := parts[:len(parts)-tldCount-1]
+	url.HostType = HostDomain
+	url.Domain = parts[len(parts)-suffixCount-1]
+	url.Subdomains = parts[:len(parts)-suffixCount-1]
+	url.Suffix = strings.Join(suffix, ".")
+	url.ICANN = icann
+	url.SpecialUse = specialUse
+	url.FullDomain = host
 
-	url := &URL{
-		Rawurl:     rawurl,
-		Subdomains: subDomains,
-		Domain:     domain,
-		TLD:        tld,
-		CTLD:       ctld,
-		FullDomain: strings.Join(append(subDomains, domain, tld, ctld), "."),
-		Path:       u.EscapedPath(),
-		Queries:    u.Query(),
+	// Unicode forms
+	if url.UnicodeFullDomain, err = toUnicode(url.FullDomain); err != nil {
+		return nil, newParseError(rawurl, ComponentHost, ReasonInvalidHost)
 	}
+	if url.UnicodeDomain, err = toUnicode(url.Domain); err != nil {
+		return nil, newParseError(rawurl, ComponentHost, ReasonInvalidHost)
+	}
+	if url.UnicodeSuffix, err = toUnicode(url.Suffix); err != nil {
+		return nil, newParseError(rawurl, ComponentHost, ReasonInvalidHost)
+	}
+
 	return url, nil
 }
 
-// IsLive returns whether the URL is up.
-func (u *URL) IsLive() bool {
-	client := nethttp.Client{
-		Timeout: 5 * time.Second,
+// String reassembles the URL from the current values of its fields, so a URL
+// whose Subdomains, Path or Queries are changed can be turned back into a string.
+// The host is assembled from Subdomains, Domain, TLD and CTLD, or taken from
+// FullDomain if the host is an IP address.
+//
+// Example Usage:
+//
+// u, _ := NewURL("https://boratanrikulu.dev/blog")
+// u.Subdomains = []string{"www"}
+// u.Queries = map[string][]string{"page": {"2"}}
+// fmt.Println(u.String()) // "https://www.boratanrikulu.dev/blog?page=2"
+//
+// The query is taken from QueryParams unless only Queries is changed.
+func (u *URL) String() string {
+	return u.AsNetURL().String()
+}
+
+// requestURL returns the URL that the checks send their requests to. It is
+// Rawurl as it is given, unless the fields of the URL are modified since it
+// is parsed; then it is String.
+func (u *URL) requestURL() string {
+	if nu, err := neturl.Parse(u.Rawurl); err == nil && u.unmodified(nu) {
+		return u.Rawurl
 	}
+	return u.String()
+}
 
-	_, err := client.Get(u.Rawurl)
-	if err != nil {
-		return false
+// unmodified tells whether the fields of the URL still have the values that
+// are parsed from nu, which is the parsed form of Rawurl.
+func (u *URL) unmodified(nu *neturl.URL) bool {
+	host := nu.Hostname()
+	if hostType, ip := parseIPHost(host); hostType != HostDomain {
+		host = ip
+	} else if ascii, err := toASCII(host); err == nil {
+		host = ascii
 	}
+	password, _ := nu.User.Password()
 
-	return true
+	return strings.EqualFold(nu.Scheme, u.Scheme) &&
+		nu.User.Username() == u.Username && password == u.Password &&
+		host == u.hostname() && nu.Port() == u.Port &&
+		nu.EscapedPath() == u.Path && nu.Fragment == u.Fragment &&
+		nu.RawQuery == u.rawQuery && u.encodeQuery() == u.rawQuery
 }
 
-// IsRecorded returns whether the URL's domain has a DNS record.
-func (u *URL) IsRecorded() bool {
-	_, err := net.LookupIP(u.FullDomain)
-	if err != nil {
-		return false
+// AsNetURL returns the URL as a *net/url.URL that is assembled from the
+// current values of its fields like String does.
+func (u *URL) AsNetURL() *neturl.URL {
+	nu := &neturl.URL{
+		Scheme:   u.Scheme,
+		Host:     u.host(),
+		RawQuery: u.encodeQuery(),
+		Fragment: u.Fragment,
+	}
+
+	switch {
+	case u.Username != "" && u.Password != "":
+		nu.User = neturl.UserPassword(u.Username, u.Password)
+	case u.Username != "":
+		nu.User = neturl.User(u.Username)
+	}
+
+	// Path keeps the escaped form of the path.
+	if path, err := neturl.PathUnescape(u.Path); err == nil {
+		nu.Path, nu.RawPath = path, u.Path
+	} else {
+		nu.Path = u.Path
+	}
+
+	return nu
+}
+
+// encodeQuery returns the raw query from QueryParams, or from Queries if
+// only Queries is changed since the URL is parsed.
+//
+// Queries is built by dropping the parameters that can not be parsed, e.g.
+// the ones with ";" or a bad escape, so the error of ParseQuery is ignored
+// to compare with it the same way.
+func (u *URL) encodeQuery() string {
+	if !equalValues(u.Queries, u.QueryParams.Values()) {
+		if parsed, _ := neturl.ParseQuery(u.rawQuery); !equalValues(u.Queries, parsed) {
+			return neturl.Values(u.Queries).Encode()
+		}
+	}
+	return u.QueryParams.Encode(QueryEncodingRaw)
+}
+
+// host returns the host of the URL with its port, e.g. "boratanrikulu.dev:8080".
+// The zone ID of an IPv6 address is not escaped as net/url.URL keeps it so.
+func (u *URL) host() string {
+	host := u.hostname()
+	if u.HostType == HostIPv6 {
+		host = "[" + host + "]"
 	}
 
-	return true
+	if u.Port != "" {
+		host += ":" + u.Port
+	}
+	return host
 }
 
-// stringSliceContains tells whether a contains x.
-func stringSliceContains(a []string, x string) bool {
-	for _, n := range a {
-		if x == n {
-			return true
+// hostname returns the host of the URL that is assembled from the current
+// values of its fields, without the port and the brackets of an IPv6 address.
+func (u *URL) hostname() string {
+	if u.HostType != HostDomain {
+		return u.FullDomain
+	}
+	labels := append([]string{}, u.Subdomains...)
+	for _, label := range []string{u.Domain, u.TLD, u.CTLD} {
+		if label != "" {
+			labels = append(labels, label)
 		}
 	}
-	return false
+	return strings.Join(labels, ".")
 }
 
-// countryTopLevelDomains includes all country-code-top-level-domains.
-// source: https://en.wikipedia.org/wiki/List_of_Internet_top-level_domains#Country_code_top-level_domains
-var countryTopLevelDomains = []string{
-	"ac", "ad", "ae", "af", "ag", "ai", "al", "am", "ao", "aq", "ar", "as", "at", "au", "aw", "ax", "az", "ba", "bb", "bd", "be", "bf", "bg", "bh", "bi", "bj", "bm", "bn", "bo", "bq", "br", "bs", "bt", "bw", "by", "bz", "ca", "cc", "cd", "cf", "cg", "ch", "ci", "ck", "cl", "cm", "cn", "co", "cr", "cu", "cv", "cw", "cx", "cy", "cz", "de", "dj", "dk", "dm", "do", "dz", "ec", "ee", "eg", "eh", "er", "es", "et", "eu", "fi", "fj", "fk", "fm", "fo", "fr", "ga", "gd", "ge", "gf", "gg", "gh", "gi", "gl", "gm", "gn", "gp", "gq", "gr", "gs", "gt", "gu", "gw", "gy", "hk", "hm", "hn", "hr", "ht", "hu", "id", "ie", "il", "im", "in", "io", "iq", "ir", "is", "it", "je", "jm", "jo", "jp", "ke", "kg", "kh", "ki", "km", "kn", "kp", "kr", "kw", "ky", "kz", "la", "lb", "lc", "li", "lk", "lr", "ls", "lt", "lu", "lv", "ly", "ma", "mc", "md", "me", "mg", "mh", "mk", "ml", "mm", "mn", "mo", "mp", "mq", "mr", "ms", "mt", "mu", "mv", "mw", "mx", "my", "mz", "na", "nc", "ne", "nf", "ng", "ni", "nl", "no", "np", "nr", "nu", "nz", "om", "pa", "pe", "pf", "pg", "ph", "pk", "pl", "pm", "pn", "pr", "ps", "pt", "pw", "py", "qa", "re", "ro", "rs", "ru", "rw", "sa", "sb", "sc", "sd", "se", "sg", "sh", "si", "sk", "sl", "sm", "sn", "so", "sr", "ss", "st", "su", "sv", "sx", "sy", "sz", "tc", "td", "tf", "tg", "th", "tj", "tk", "tl", "tm", "tn", "to", "tr", "tt", "tv", "tw", "tz", "ua", "ug", "uk", "us", "uy", "uz", "va", "vc", "ve", "vg", "vi", "vn", "vu", "wf", "ws", "ye", "yt", "za", "zm", "zw",
+// PortOrDefault returns the port of the URL, or the default port of its
+// scheme if the URL does not have one, e.g. "443" for "https://boratanrikulu.dev".
+func (u *URL) PortOrDefault() string {
+	if u.Port != "" {
+		return u.Port
+	}
+	return DefaultPort(u.Scheme)
 }
 
-// topLevelDomains includes all top-level-domains.
-// source: https://data.iana.org/TLD/tlds-alpha-by-domain.txt
-// taken-date: Thu Jan 28 07:07:02 2021 UTC
-// note: ctlds are removed from the list.
-var topLevelDomains = []string{
-	"aaa", "aarp", "abarth", "abb", "abbott", "abbvie", "abc", "able", "abogado", "abudhabi", "academy", "accenture", "accountant", "accountants", "aco", "actor", "adac", "ads", "adult", "aeg", "aero", "aetna", "afamilycompany", "afl", "africa", "agakhan", "agency", "aig", "airbus", "airforce", "airtel", "akdn", "alfaromeo", "alibaba", "alipay", "allfinanz", "allstate", "ally", "alsace", "alstom", "amazon", "americanexpress", "americanfamily", "amex", "amfam", "amica", "amsterdam", "analytics", "android", "anquan", "anz", "aol", "apartments", "app", "apple", "aquarelle", "arab", "aramco", "archi", "army", "arpa", "art", "arte", "asda", "asia", "associates", "athleta", "attorney", "auction", "audi", "audible", "audio", "auspost", "author", "auto", "autos", "avianca", "aws", "axa", "azure", "baby", "baidu", "banamex", "bananarepublic", "band", "bank", "bar", "barcelona", "barclaycard", "barclays", "barefoot", "bargains", "baseball", "basketball", "bauhaus", "bayern", "bbc", "bbt", "bbva", "bcg", "bcn", "beats", "beauty", "beer", "bentley", "berlin", "best", "bestbuy", "bet", "bharti", "bible", "bid", "bike", "bing", "bingo", "bio", "biz", "black", "blackfriday", "blockbuster", "blog", "bloomberg", "blue", "bms", "bmw", "bnpparibas", "boats", "boehringer", "bofa", "bom", "bond", "boo", "book", "booking", "bosch", "bostik", "boston", "bot", "boutique", "box", "bradesco", "bridgestone", "broadway", "broker", "brother", "brussels", "budapest", "bugatti", "build", "builders", "business", "buy", "buzz", "bv", "bzh", "cab", "cafe", "cal", "call", "calvinklein", "cam", "camera", "camp", "cancerresearch", "canon", "capetown", "capital", "capitalone", "car", "caravan", "cards", "care", "career", "careers", "cars", "casa", "case", "caseih", "cash", "casino", "cat", "catering", "catholic", "cba", "cbn", "cbre", "cbs", "center", "ceo", "cern", "cfa", "cfd", "chanel", "channel", "charity", "chase", "chat", "cheap", "chintai", "christmas", "chrome", "church", "cipriani", "circle", "cisco", "citadel", "citi", "citic", "city", "cityeats", "claims", "cleaning", "click", "clinic", "clinique", "clothing", "cloud", "club", "clubmed", "coach", "codes", "coffee", "college", "cologne", "com", "comcast", "commbank", "community", "company", "compare", "computer", "comsec", "condos", "construction", "consulting", "contact", "contractors", "cooking", "cookingchannel", "cool", "coop", "corsica", "country", "coupon", "coupons", "courses", "cpa", "credit", "creditcard", "creditunion", "cricket", "crown", "crs", "cruise", "cruises", "csc", "cuisinella", "cymru", "cyou", "dabur", "dad", "dance", "data", "date", "dating", "datsun", "day", "dclk", "dds", "deal", "dealer", "deals", "degree", "delivery", "dell", "deloitte", "delta", "democrat", "dental", "dentist", "desi", "design", "dev", "dhl", "diamonds", "diet", "digital", "direct", "directory", "discount", "discover", "dish", "diy", "dnp", "docs", "doctor", "dog", "domains", "dot", "download", "drive", "dtv", "dubai", "duck", "dunlop", "dupont", "durban", "dvag", "dvr", "earth", "eat", "eco", "edeka", "edu", "education", "email", "emerck", "energy", "engineer", "engineering", "enterprises", "epson", "equipment", "ericsson", "erni", "esq", "estate", "etisalat", "eurovision", "eus", "events", "exchange", "expert", "exposed", "express", "extraspace", "fage", "fail", "fairwinds", "faith", "family", "fan", "fans", "farm", "farmers", "fashion", "fast", "fedex", "feedback", "ferrari", "ferrero", "fiat", "fidelity", "fido", "film", "final", "finance", "financial", "fire", "firestone", "firmdale", "fish", "fishing", "fit", "fitness", "flickr", "flights", "flir", "florist", "flowers", "fly", "foo", "food", "foodnetwork", "football", "ford", "forex", "forsale", "forum", "foundation", "fox", "free", "fresenius", "frl", "frogans", "frontdoor", "frontier", "ftr", "fujitsu", "fujixerox", "fun", "fund", "furniture", "futbol", "fyi", "gal", "gallery", "gallo", "gallup", "game", "games", "gap", "garden", "gay", "gb", "gbiz", "gdn", "gea", "gent", "genting", "george", "ggee", "gift", "gifts", "gives", "giving", "glade", "glass", "gle", "global", "globo", "gmail", "gmbh", "gmo", "gmx", "godaddy", "gold", "goldpoint", "golf", "goo", "goodyear", "goog", "google", "gop", "got", "gov", "grainger", "graphics", "gratis", "green", "gripe", "grocery", "group", "guardian", "gucci", "guge", "guide", "guitars", "guru", "hair", "hamburg", "hangout", "haus", "hbo", "hdfc", "hdfcbank", "health", "healthcare", "help", "helsinki", "here", "hermes", "hgtv", "hiphop", "hisamitsu", "hitachi", "hiv", "hkt", "hockey", "holdings", "holiday", "homedepot", "homegoods", "homes", "homesense", "honda", "horse", "hospital", "host", "hosting", "hot", "hoteles", "hotels", "hotmail", "house", "how", "hsbc", "hughes", "hyatt", "hyundai", "ibm", "icbc", "ice", "icu", "ieee", "ifm", "ikano", "imamat", "imdb", "immo", "immobilien", "inc", "industries", "infiniti", "info", "ing", "ink", "institute", "insurance", "insure", "int", "international", "intuit", "investments", "ipiranga", "irish", "ismaili", "ist", "istanbul", "itau", "itv", "iveco", "jaguar", "java", "jcb", "jeep", "jetzt", "jewelry", "jio", "jll", "jmp", "jnj", "jobs", "joburg", "jot", "joy", "jpmorgan", "jprs", "juegos", "juniper", "kaufen", "kddi", "kerryhotels", "kerrylogistics", "kerryproperties", "kfh", "kia", "kim", "kinder", "kindle", "kitchen", "kiwi", "koeln", "komatsu", "kosher", "kpmg", "kpn", "krd", "kred", "kuokgroup", "kyoto", "lacaixa", "lamborghini", "lamer", "lancaster", "lancia", "land", "landrover", "lanxess", "lasalle", "lat", "latino", "latrobe", "law", "lawyer", "lds", "lease", "leclerc", "lefrak", "legal", "lego", "lexus", "lgbt", "lidl", "life", "lifeinsurance", "lifestyle", "lighting", "like", "lilly", "limited", "limo", "lincoln", "linde", "link", "lipsy", "live", "living", "lixil", "llc", "llp", "loan", "loans", "locker", "locus", "loft", "lol", "london", "lotte", "lotto", "love", "lpl", "lplfinancial", "ltd", "ltda", "lundbeck", "luxe", "luxury", "macys", "madrid", "maif", "maison", "makeup", "man", "management", "mango", "map", "market", "marketing", "markets", "marriott", "marshalls", "maserati", "mattel", "mba", "mckinsey", "med", "media", "meet", "melbourne", "meme", "memorial", "men", "menu", "merckmsd", "miami", "microsoft", "mil", "mini", "mint", "mit", "mitsubishi", "mlb", "mls", "mma", "mobi", "mobile", "moda", "moe", "moi", "mom", "monash", "money", "monster", "mormon", "mortgage", "moscow", "moto", "motorcycles", "mov", "movie", "msd", "mtn", "mtr", "museum", "mutual", "nab", "nagoya", "name", "nationwide", "natura", "navy", "nba", "nec", "net", "netbank", "netflix", "network", "neustar", "new", "newholland", "news", "next", "nextdirect", "nexus", "nfl", "ngo", "nhk", "nico", "nike", "nikon", "ninja", "nissan", "nissay", "nokia", "northwesternmutual", "norton", "now", "nowruz", "nowtv", "nra", "nrw", "ntt", "nyc", "obi", "observer", "off", "office", "okinawa", "olayan", "olayangroup", "oldnavy", "ollo", "omega", "one", "ong", "onl", "online", "onyourside", "ooo", "open", "oracle", "orange", "org", "organic", "origins", "osaka", "otsuka", "ott", "ovh", "page", "panasonic", "paris", "pars", "partners", "parts", "party", "passagens", "pay", "pccw", "pet", "pfizer", "pharmacy", "phd", "philips", "phone", "photo", "photography", "photos", "physio", "pics", "pictet", "pictures", "pid", "pin", "ping", "pink", "pioneer", "pizza", "place", "play", "playstation", "plumbing", "plus", "pnc", "pohl", "poker", "politie", "porn", "post", "pramerica", "praxi", "press", "prime", "pro", "prod", "productions", "prof", "progressive", "promo", "properties", "property", "protection", "pru", "prudential", "pub", "pwc", "qpon", "quebec", "quest", "qvc", "racing", "radio", "raid", "read", "realestate", "realtor", "realty", "recipes", "red", "redstone", "redumbrella", "rehab", "reise", "reisen", "reit", "reliance", "ren", "rent", "rentals", "repair", "report", "republican", "rest", "restaurant", "review", "reviews", "rexroth", "rich", "richardli", "ricoh", "ril", "rio", "rip", "rmit", "rocher", "rocks", "rodeo", "rogers", "room", "rsvp", "rugby", "ruhr", "run", "rwe", "ryukyu", "saarland", "safe", "safety", "sakura", "sale", "salon", "samsclub", "samsung", "sandvik", "sandvikcoromant", "sanofi", "sap", "sarl", "sas", "save", "saxo", "sbi", "sbs", "sca", "scb", "schaeffler", "schmidt", "scholarships", "school", "schule", "schwarz", "science", "scjohnson", "scot", "search", "seat", "secure", "security", "seek", "select", "sener", "services", "ses", "seven", "sew", "sex", "sexy", "sfr", "shangrila", "sharp", "shaw", "shell", "shia", "shiksha", "shoes", "shop", "shopping", "shouji", "show", "showtime", "silk", "sina", "singles", "site", "sj", "ski", "skin", "sky", "skype", "sling", "smart", "smile", "sncf", "soccer", "social", "softbank", "software", "sohu", "solar", "solutions", "song", "sony", "soy", "spa", "space", "sport", "spot", "spreadbetting", "srl", "stada", "staples", "star", "statebank", "statefarm", "stc", "stcgroup", "stockholm", "storage", "store", "stream", "studio", "study", "style", "sucks", "supplies", "supply", "support", "surf", "surgery", "suzuki", "swatch", "swiftcover", "swiss", "sydney", "systems", "tab", "taipei", "talk", "taobao", "target", "tatamotors", "tatar", "tattoo", "tax", "taxi", "tci", "tdk", "team", "tech", "technology", "tel", "temasek", "tennis", "teva", "thd", "theater", "theatre", "tiaa", "tickets", "tienda", "tiffany", "tips", "tires", "tirol", "tjmaxx", "tjx", "tkmaxx", "tmall", "today", "tokyo", "tools", "top", "toray", "toshiba", "total", "tours", "town", "toyota", "toys", "trade", "trading", "training", "travel", "travelchannel", "travelers", "travelersinsurance", "trust", "trv", "tube", "tui", "tunes", "tushu", "tvs", "ubank", "ubs", "unicom", "university", "uno", "uol", "ups", "vacations", "vana", "vanguard", "vegas", "ventures", "verisign", "versicherung", "vet", "viajes", "video", "vig", "viking", "villas", "vin", "vip", "virgin", "visa", "vision", "viva", "vivo", "vlaanderen", "vodka", "volkswagen", "volvo", "vote", "voting", "voto", "voyage", "vuelos", "wales", "walmart", "walter", "wang", "wanggou", "watch", "watches", "weather", "weatherchannel", "webcam", "weber", "website", "wed", "wedding", "weibo", "weir", "whoswho", "wien", "wiki", "williamhill", "win", "windows", "wine", "winners", "wme", "wolterskluwer", "woodside", "work", "works", "world", "wow", "wtc", "wtf", "xbox", "xerox", "xfinity", "xihuan", "xin", "xn--11b4c3d", "xn--1ck2e1b", "xn--1qqw23a", "xn--2scrj9c", "xn--30rr7y", "xn--3bst00m", "xn--3ds443g", "xn--3e0b707e", "xn--3hcrj9c", "xn--3oq18vl8pn36a", "xn--3pxu8k", "xn--42c2d9a", "xn--45br5cyl", "xn--45brj9c", "xn--45q11c", "xn--4gbrim", "xn--54b7fta0cc", "xn--55qw42g", "xn--55qx5d", "xn--5su34j936bgsg", "xn--5tzm5g", "xn--6frz82g", "xn--6qq986b3xl", "xn--80adxhks", "xn--80ao21a", "xn--80aqecdr1a", "xn--80asehdb", "xn--80aswg", "xn--8y0a063a", "xn--90a3ac", "xn--90ae", "xn--90ais", "xn--9dbq2a", "xn--9et52u", "xn--9krt00a", "xn--b4w605ferd", "xn--bck1b9a5dre4c", "xn--c1avg", "xn--c2br7g", "xn--cck2b3b", "xn--cckwcxetd", "xn--cg4bki", "xn--clchc0ea0b2g2a9gcd", "xn--czr694b", "xn--czrs0t", "xn--czru2d", "xn--d1acj3b", "xn--d1alf", "xn--e1a4c", "xn--eckvdtc9d", "xn--efvy88h", "xn--fct429k", "xn--fhbei", "xn--fiq228c5hs", "xn--fiq64b", "xn--fiqs8s", "xn--fiqz9s", "xn--fjq720a", "xn--flw351e", "xn--fpcrj9c3d", "xn--fzc2c9e2c", "xn--fzys8d69uvgm", "xn--g2xx48c", "xn--gckr3f0f", "xn--gecrj9c", "xn--gk3at1e", "xn--h2breg3eve", "xn--h2brj9c", "xn--h2brj9c8c", "xn--hxt814e", "xn--i1b6b1a6a2e", "xn--imr513n", "xn--io0a7i", "xn--j1aef", "xn--j1amh", "xn--j6w193g", "xn--jlq480n2rg", "xn--jlq61u9w7b", "xn--jvr189m", "xn--kcrx77d1x4a", "xn--kprw13d", "xn--kpry57d", "xn--kput3i", "xn--l1acc", "xn--lgbbat1ad8j", "xn--mgb9awbf", "xn--mgba3a3ejt", "xn--mgba3a4f16a", "xn--mgba7c0bbn0a", "xn--mgbaakc7dvf", "xn--mgbaam7a8h", "xn--mgbab2bd", "xn--mgbah1a3hjkrd", "xn--mgbai9azgqp6j", "xn--mgbayh7gpa", "xn--mgbbh1a", "xn--mgbbh1a71e", "xn--mgbc0a9azcg", "xn--mgbca7dzdo", "xn--mgbcpq6gpa1a", "xn--mgberp4a5d4ar", "xn--mgbgu82a", "xn--mgbi4ecexp", "xn--mgbpl2fh", "xn--mgbt3dhd", "xn--mgbtx2b", "xn--mgbx4cd0ab", "xn--mix891f", "xn--mk1bu44c", "xn--mxtq1m", "xn--ngbc5azd", "xn--ngbe9e0a", "xn--ngbrx", "xn--node", "xn--nqv7f", "xn--nqv7fs00ema", "xn--nyqy26a", "xn--o3cw4h", "xn--ogbpf8fl", "xn--otu796d", "xn--p1acf", "xn--p1ai", "xn--pgbs0dh", "xn--pssy2u", "xn--q7ce6a", "xn--q9jyb4c", "xn--qcka1pmc", "xn--qxa6a", "xn--qxam", "xn--rhqv96g", "xn--rovu88b", "xn--rvc1e0am3e", "xn--s9brj9c", "xn--ses554g", "xn--t60b56a", "xn--tckwe", "xn--tiq49xqyj", "xn--unup4y", "xn--vermgensberater-ctb", "xn--vermgensberatung-pwb", "xn--vhquv", "xn--vuq861b", "xn--w4r85el8fhu5dnra", "xn--w4rs40l", "xn--wgbh1c", "xn--wgbl6a", "xn--xhq521b", "xn--xkc2al3hye2a", "xn--xkc2dl3a5ee0h", "xn--y9a3aq", "xn--yfro4i67o", "xn--ygbi2ammx", "xn--zfr164b", "xxx", "xyz", "yachts", "yahoo", "yamaxun", "yandex", "yodobashi", "yoga", "yokohama", "you", "youtube", "yun", "zappos", "zara", "zero", "zip", "zone", "zuerich", "bq", "eh",
+// DefaultPort returns the default port of the scheme, e.g. "80" for "http".
+// An empty string is returned for an unknown scheme.
+func DefaultPort(scheme string) string {
+	return defaultPorts[strings.ToLower(scheme)]
+}
+
+// defaultPorts includes the default ports of the well-known schemes.
+var defaultPorts = map[string]string{
+	"ftp":    "21",
+	"gopher": "70",
+	"http":   "80",
+	"https":  "443",
+	"imap":   "143",
+	"imaps":  "993",
+	"ldap":   "389",
+	"ldaps":  "636",
+	"nntp":   "119",
+	"pop3":   "110",
+	"pop3s":  "995",
+	"redis":  "6379",
+	"rtsp":   "554",
+	"sftp":   "22",
+	"smtp":   "25",
+	"ssh":    "22",
+	"telnet": "23",
+	"ws":     "80",
+	"wss":    "443",
+}
+
+// IsLive returns whether the URL is up.
+// It is a shorthand for CheckLive with the default options, so the URL is
+// up if it answers with a status code between 200 and 399 in 5 seconds.
+func (u *URL) IsLive() bool {
+	return u.CheckLive(context.Background(), LiveOptions{}).Live
+}
+
+// IsRecorded returns whether the URL's domain has a DNS record.
+// It is a shorthand for LookupDNS with the A and AAAA records.
+func (u *URL) IsRecorded() bool {
+	return u.LookupDNS(context.Background(), DNSOptions{Types: RecordA | RecordAAAA}).Recorded
 }
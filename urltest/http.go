@@ -0,0 +1,128 @@
+package urltest
+
+import (
+	"errors"
+	"io/ioutil"
+	"net"
+	nethttp "net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Response is a response of the HTTPClient.
+type Response struct {
+	StatusCode int
+	Header     nethttp.Header
+	Body       string
+	// Delay is the time that the response takes. The request fails if its
+	// context is done before.
+	Delay time.Duration
+}
+
+// HTTPClient is an in-memory HTTP client that implements url.HTTPClient.
+// It can be used as the Transport of a *http.Client as well.
+// The requests to the URLs that are not handled fail as if the connection is
+// refused. It does not follow the redirects. It is safe for concurrent use.
+type HTTPClient struct {
+	mu        sync.Mutex
+	responses map[string]Response
+	errs      map[string]error
+	requests  []*nethttp.Request
+}
+
+// NewHTTPClient returns an HTTPClient that does not handle any URL.
+func NewHTTPClient() *HTTPClient {
+	return &HTTPClient{
+		responses: make(map[string]Response),
+		errs:      make(map[string]error),
+	}
+}
+
+// Handle makes the client answer the requests to the URL with the response.
+// The URL is matched without its fragment, e.g. "https://example.com/a?b=c".
+func (c *HTTPClient) Handle(rawurl string, resp Response) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.responses[rawurl] = resp
+}
+
+// Redirect makes the client redirect the requests to the URL to the location
+// with the status code, e.g. http.StatusMovedPermanently.
+func (c *HTTPClient) Redirect(rawurl, location string, code int) {
+	c.Handle(rawurl, Response{StatusCode: code, Header: nethttp.Header{"Location": {location}}})
+}
+
+// Fail makes the requests to the URL fail with the error.
+func (c *HTTPClient) Fail(rawurl string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.errs[rawurl] = err
+}
+
+// Requests returns the requests that are sent, in their order.
+func (c *HTTPClient) Requests() []*nethttp.Request {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*nethttp.Request(nil), c.requests...)
+}
+
+// Do answers the request.
+func (c *HTTPClient) Do(req *nethttp.Request) (*nethttp.Response, error) {
+	return c.RoundTrip(req)
+}
+
+// RoundTrip answers the request.
+func (c *HTTPClient) RoundTrip(req *nethttp.Request) (*nethttp.Response, error) {
+	u := *req.URL
+	u.Fragment = ""
+	rawurl := u.String()
+
+	c.mu.Lock()
+	c.requests = append(c.requests, req)
+	resp, handled := c.responses[rawurl]
+	err, failed := c.errs[rawurl]
+	c.mu.Unlock()
+
+	if resp.Delay > 0 {
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(resp.Delay):
+		}
+	}
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+	switch {
+	case failed:
+		return nil, err
+	case !handled:
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	}
+
+	code := resp.StatusCode
+	if code == 0 {
+		code = nethttp.StatusOK
+	}
+	header := nethttp.Header{}
+	for key, values := range resp.Header {
+		header[key] = append([]string(nil), values...)
+	}
+	body := resp.Body
+	if req.Method == nethttp.MethodHead {
+		body = ""
+	}
+	return &nethttp.Response{
+		Status:        strconv.Itoa(code) + " " + nethttp.StatusText(code),
+		StatusCode:    code,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,76 @@
+package urltest
+
+import (
+	"context"
+	"errors"
+	nethttp "net/http"
+	"testing"
+	"time"
+
+	"github.com/zeoagency/url"
+)
+
+var (
+	_ url.HTTPClient       = (*HTTPClient)(nil)
+	_ nethttp.RoundTripper = (*HTTPClient)(nil)
+)
+
+func TestHTTPClient(t *testing.T) {
+	client := NewHTTPClient()
+	client.Redirect("http://boratanrikulu.dev", "https://boratanrikulu.dev/", nethttp.StatusMovedPermanently)
+	client.Handle("https://boratanrikulu.dev/", Response{StatusCode: nethttp.StatusOK, Body: "ok"})
+	client.Handle("https://boratanrikulu.dev/slow", Response{Delay: time.Second})
+	client.Fail("https://boratanrikulu.dev/reset", errors.New("connection reset by peer"))
+
+	var testValues = []struct {
+		Input            string
+		WantedLive       bool
+		WantedStatusCode int
+		WantedRedirects  int
+		WantedErrorClass url.ErrorClass
+	}{
+		{Input: "http://boratanrikulu.dev", WantedLive: true, WantedStatusCode: 200, WantedRedirects: 1},
+		{Input: "https://boratanrikulu.dev/#top", WantedLive: true, WantedStatusCode: 200},
+		{Input: "https://boratanrikulu.dev/slow", WantedErrorClass: url.ErrorClassTimeout},
+		{Input: "https://boratanrikulu.dev/reset", WantedErrorClass: url.ErrorClassOther},
+		{Input: "https://seo.do", WantedErrorClass: url.ErrorClassConnection},
+	}
+
+	for _, testValue := range testValues {
+		u, err := url.NewURL(testValue.Input)
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Input)
+		}
+
+		result := u.CheckLive(context.Background(), url.LiveOptions{Client: client, Timeout: 20 * time.Millisecond})
+		if result.Live != testValue.WantedLive || result.StatusCode != testValue.WantedStatusCode {
+			t.Fatalf("[%s] Result is wrong: Wanted: \"%t %d\" - Got: \"%t %d\" (%v)", testValue.Input, testValue.WantedLive, testValue.WantedStatusCode, result.Live, result.StatusCode, result.Err)
+		}
+		if len(result.Redirects) != testValue.WantedRedirects {
+			t.Fatalf("[%s] Redirects are wrong: Wanted: \"%d\" - Got: \"%d\"", testValue.Input, testValue.WantedRedirects, len(result.Redirects))
+		}
+		if result.ErrorClass != testValue.WantedErrorClass {
+			t.Fatalf("[%s] ErrorClass is wrong: Wanted: \"%s\" - Got: \"%s\" (%v)", testValue.Input, testValue.WantedErrorClass, result.ErrorClass, result.Err)
+		}
+	}
+
+	requests := client.Requests()
+	if len(requests) == 0 || requests[0].Method != nethttp.MethodHead || requests[0].UserAgent() != url.DefaultUserAgent {
+		t.Fatalf("Requests are wrong: Got: %v", requests)
+	}
+}
+
+func TestHTTPClientTransport(t *testing.T) {
+	client := NewHTTPClient()
+	client.Handle("https://boratanrikulu.dev/", Response{Header: nethttp.Header{"X-Test": {"yes"}}, Body: "hello"})
+
+	resp, err := (&nethttp.Client{Transport: client}).Get("https://boratanrikulu.dev/")
+	if err != nil {
+		t.Fatalf("Error occur: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != nethttp.StatusOK || resp.Header.Get("X-Test") != "yes" || resp.ContentLength != 5 {
+		t.Fatalf("Response is wrong: Got: %d %v %d", resp.StatusCode, resp.Header, resp.ContentLength)
+	}
+}
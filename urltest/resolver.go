@@ -0,0 +1,175 @@
+// Package urltest provides in-memory fakes of the DNS resolver and the HTTP
+// client that the checks of the url package go through, so the checks can be
+// tested without network.
+//
+// Example Usage:
+//
+// resolver := urltest.NewResolver()
+// resolver.AddIP("boratanrikulu.dev", "185.199.108.153")
+// url.DefaultResolver = resolver
+//
+// u, _ := url.NewURL("https://boratanrikulu.dev")
+// fmt.Println(u.IsRecorded()) // true
+package urltest
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+)
+
+// Records are the DNS records of a host.
+type Records struct {
+	IPs   []net.IP
+	CNAME string
+	MX    []*net.MX
+	NS    []*net.NS
+	TXT   []string
+}
+
+// Resolver is an in-memory DNS resolver that implements url.Resolver.
+// The hosts that are not added are not found. It is safe for concurrent use.
+type Resolver struct {
+	mu      sync.Mutex
+	hosts   map[string]Records
+	errs    map[string]error
+	lookups int
+}
+
+// NewResolver returns an empty Resolver.
+func NewResolver() *Resolver {
+	return &Resolver{
+		hosts: make(map[string]Records),
+		errs:  make(map[string]error),
+	}
+}
+
+// Add sets the records of the host.
+func (r *Resolver) Add(host string, records Records) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hosts[canonicalHost(host)] = records
+}
+
+// AddIP adds the IP addresses to the records of the host.
+func (r *Resolver) AddIP(host string, ips ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	records := r.hosts[canonicalHost(host)]
+	for _, ip := range ips {
+		records.IPs = append(records.IPs, net.ParseIP(ip))
+	}
+	r.hosts[canonicalHost(host)] = records
+}
+
+// Fail makes the lookups of the host fail with the error,
+// e.g. ServFail(host) or Timeout(host).
+func (r *Resolver) Fail(host string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errs[canonicalHost(host)] = err
+}
+
+// Lookups returns the number of the lookups that are made.
+func (r *Resolver) Lookups() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lookups
+}
+
+// records returns the records of the host, or the error of the lookup.
+func (r *Resolver) records(ctx context.Context, host string) (Records, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lookups++
+
+	host = canonicalHost(host)
+	if err := ctx.Err(); err != nil {
+		return Records{}, &net.DNSError{Err: err.Error(), Name: host, IsTimeout: err == context.DeadlineExceeded}
+	}
+	if err, ok := r.errs[host]; ok {
+		return Records{}, err
+	}
+	records, ok := r.hosts[host]
+	if !ok {
+		return Records{}, NotFound(host)
+	}
+	return records, nil
+}
+
+// LookupIPAddr returns the IP addresses of the host.
+func (r *Resolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	records, err := r.records(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if len(records.IPs) == 0 {
+		return nil, NotFound(host)
+	}
+	addrs := make([]net.IPAddr, 0, len(records.IPs))
+	for _, ip := range records.IPs {
+		addrs = append(addrs, net.IPAddr{IP: ip})
+	}
+	return addrs, nil
+}
+
+// LookupCNAME returns the canonical name of the host, or the host itself
+// if it is not an alias, like *net.Resolver does.
+func (r *Resolver) LookupCNAME(ctx context.Context, host string) (string, error) {
+	records, err := r.records(ctx, host)
+	if err != nil {
+		return "", err
+	}
+	if records.CNAME == "" {
+		return canonicalHost(host) + ".", nil
+	}
+	return canonicalHost(records.CNAME) + ".", nil
+}
+
+// LookupMX returns the MX records of the name.
+func (r *Resolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	records, err := r.records(ctx, name)
+	if err == nil && len(records.MX) == 0 {
+		err = NotFound(name)
+	}
+	return records.MX, err
+}
+
+// LookupNS returns the NS records of the name.
+func (r *Resolver) LookupNS(ctx context.Context, name string) ([]*net.NS, error) {
+	records, err := r.records(ctx, name)
+	if err == nil && len(records.NS) == 0 {
+		err = NotFound(name)
+	}
+	return records.NS, err
+}
+
+// LookupTXT returns the TXT records of the name.
+func (r *Resolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	records, err := r.records(ctx, name)
+	if err == nil && len(records.TXT) == 0 {
+		err = NotFound(name)
+	}
+	return records.TXT, err
+}
+
+// NotFound returns the error of a host that does not exist (NXDOMAIN).
+func NotFound(host string) error {
+	return &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+// ServFail returns the error of a name server that could not answer (SERVFAIL).
+func ServFail(host string) error {
+	return &net.DNSError{Err: "server misbehaving", Name: host, IsTemporary: true}
+}
+
+// Timeout returns the error of a lookup that timed out.
+func Timeout(host string) error {
+	return &net.DNSError{Err: "i/o timeout", Name: host, IsTimeout: true, IsTemporary: true}
+}
+
+// canonicalHost lowercases the host and removes its trailing dot.
+func canonicalHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
@@ -0,0 +1,51 @@
+package urltest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zeoagency/url"
+)
+
+var _ url.Resolver = (*Resolver)(nil)
+
+func TestResolver(t *testing.T) {
+	resolver := NewResolver()
+	resolver.AddIP("boratanrikulu.dev", "185.199.108.153", "2606:50c0:8000::153")
+	resolver.Add("www.boratanrikulu.dev", Records{CNAME: "boratanrikulu.github.io", TXT: []string{"hello"}})
+	resolver.Fail("broken.boratanrikulu.dev", ServFail("broken.boratanrikulu.dev"))
+	resolver.Fail("slow.boratanrikulu.dev", Timeout("slow.boratanrikulu.dev"))
+
+	var testValues = []struct {
+		Input        string
+		WantedStatus url.DNSStatus
+		WantedA      int
+		WantedAAAA   int
+		WantedCNAME  string
+	}{
+		{Input: "https://boratanrikulu.dev", WantedStatus: url.DNSNoError, WantedA: 1, WantedAAAA: 1},
+		{Input: "https://WWW.boratanrikulu.dev", WantedStatus: url.DNSNoError, WantedCNAME: "boratanrikulu.github.io"},
+		{Input: "https://nope.boratanrikulu.dev", WantedStatus: url.DNSNXDomain},
+		{Input: "https://broken.boratanrikulu.dev", WantedStatus: url.DNSServFail},
+		{Input: "https://slow.boratanrikulu.dev", WantedStatus: url.DNSTimeout},
+	}
+
+	for _, testValue := range testValues {
+		u, err := url.NewURL(testValue.Input)
+		if err != nil {
+			t.Fatalf("Error occur: %s - %s", err, testValue.Input)
+		}
+
+		result := u.LookupDNS(context.Background(), url.DNSOptions{Resolver: resolver})
+		if result.Status != testValue.WantedStatus {
+			t.Fatalf("[%s] Status is wrong: Wanted: \"%s\" - Got: \"%s\" (%v)", testValue.Input, testValue.WantedStatus, result.Status, result.Err)
+		}
+		if len(result.A) != testValue.WantedA || len(result.AAAA) != testValue.WantedAAAA || result.CNAME != testValue.WantedCNAME {
+			t.Fatalf("[%s] Records are wrong: Got: %v %v \"%s\"", testValue.Input, result.A, result.AAAA, result.CNAME)
+		}
+	}
+
+	if resolver.Lookups() == 0 {
+		t.Fatalf("Lookups must be counted")
+	}
+}